@@ -0,0 +1,288 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/barcode"
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+	"github.com/google/uuid"
+)
+
+// valueKind is the type of a positional argument or flag value.
+type valueKind int
+
+const (
+	kindString valueKind = iota
+	kindUUID
+	kindBool
+	kindChoice
+	kindInt
+	kindBarcode
+)
+
+// argSpec declares a positional argument. A variadic argument must come last
+// and takes every remaining word.
+type argSpec struct {
+	name     string
+	kind     valueKind
+	choices  []string
+	optional bool
+	variadic bool
+	def      string
+	// complete, if set, suggests values for the argument.
+	complete func(*digitalshelfapi.Session) []string
+}
+
+// flagSpec declares a --name flag. Bool flags take no value; every other
+// kind takes the next word, or a value after '='.
+type flagSpec struct {
+	name        string
+	kind        valueKind
+	choices     []string
+	description string
+	def         string
+	complete    func(*digitalshelfapi.Session) []string
+}
+
+// globalFlags are accepted by every command.
+var globalFlags = []flagSpec{
+	{
+		name:        "format",
+		kind:        kindChoice,
+		choices:     outputFormatNames(),
+		description: "Output format for this command: table, json, jsonl, csv or yaml",
+	},
+	{name: "help", kind: kindBool, description: "Show help for this command"},
+}
+
+// commandArgs holds the validated arguments and flags of a command, with
+// defaults filled in.
+type commandArgs struct {
+	values map[string]string
+	lists  map[string][]string
+	flags  map[string]string
+}
+
+// arg returns a positional argument, or "" if an optional one was omitted.
+func (a commandArgs) arg(name string) string {
+	return a.values[name]
+}
+
+// id returns a positional argument declared as kindUUID.
+func (a commandArgs) id(name string) uuid.UUID {
+	id, _ := uuid.Parse(a.values[name])
+	return id
+}
+
+// barcode returns a positional argument declared as kindBarcode, normalized
+// so UPC-E and ISBN-10 codes are looked up as UPC-A and ISBN-13.
+func (a commandArgs) barcode(name string) string {
+	code, _ := barcode.Normalize(a.values[name])
+	return code
+}
+
+// list returns the words taken by a variadic argument.
+func (a commandArgs) list(name string) []string {
+	return a.lists[name]
+}
+
+// flag returns the value of a flag, or "" if it was not given and has no
+// default.
+func (a commandArgs) flag(name string) string {
+	return a.flags[name]
+}
+
+// number returns a flag declared as kindInt, or 0 if it was not given and
+// has no default.
+func (a commandArgs) number(name string) int {
+	n, _ := strconv.Atoi(a.flags[name])
+	return n
+}
+
+// enabled reports whether a bool flag was given.
+func (a commandArgs) enabled(name string) bool {
+	return a.flags[name] == "true"
+}
+
+// findCommand walks from command down the subcommands named by words and
+// returns the command that runs, the words that name it, and the words left
+// over as its arguments.
+func findCommand(command cliCommand, words []string) (cliCommand, string, []string, error) {
+	path := command.name
+	for len(command.subcommands) > 0 {
+		if len(words) > 0 {
+			sub := findSubcommand(command.subcommands, words[0])
+			if sub != nil {
+				command = *sub
+				path += " " + sub.name
+				words = words[1:]
+				continue
+			}
+		}
+		if command.callback != nil || slices.Contains(words, "--help") {
+			break
+		}
+		if len(words) == 0 || strings.HasPrefix(words[0], "--") {
+			return command, path, words, usageErrorf("please specify one of: %s\nRun 'help %s' for details", strings.Join(subcommandList(command), ", "), path)
+		}
+		return command, path, words, usageErrorf("unknown %s command: %s\nRun 'help %s' for details", path, words[0], path)
+	}
+	return command, path, words, nil
+}
+
+// parseArgs checks words against the arguments and flags command declares.
+func parseArgs(command cliCommand, path string, words []string) (commandArgs, error) {
+	parsed := commandArgs{
+		values: map[string]string{},
+		lists:  map[string][]string{},
+		flags:  map[string]string{},
+	}
+	flags := slices.Concat(command.flags, globalFlags)
+	usageErr := func(format string, a ...any) error {
+		return usageErrorf("%s\nUsage: %s", fmt.Sprintf(format, a...), commandUsage(path, command))
+	}
+
+	var positionals []string
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		if word == "--" {
+			positionals = append(positionals, words[i+1:]...)
+			break
+		}
+		if !strings.HasPrefix(word, "--") || len(word) == 2 {
+			positionals = append(positionals, word)
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(word[2:], "=")
+		spec := findFlag(flags, name)
+		if spec == nil {
+			return parsed, usageErr("unknown flag: --%s", name)
+		}
+		if spec.kind == kindBool {
+			if !hasValue {
+				value = "true"
+			}
+			if value != "true" && value != "false" {
+				return parsed, usageErr("--%s does not take a value", name)
+			}
+		} else if !hasValue {
+			if i+1 >= len(words) {
+				return parsed, usageErr("--%s needs a value", name)
+			}
+			i++
+			value = words[i]
+		}
+		err := validateValue(spec.kind, spec.choices, value)
+		if err != nil {
+			return parsed, usageErr("invalid --%s: %v", name, err)
+		}
+		parsed.flags[name] = value
+	}
+	for _, spec := range flags {
+		if _, ok := parsed.flags[spec.name]; !ok && spec.def != "" {
+			parsed.flags[spec.name] = spec.def
+		}
+	}
+	if parsed.enabled("help") {
+		return parsed, nil
+	}
+
+	for _, spec := range command.args {
+		if spec.variadic {
+			if len(positionals) == 0 && !spec.optional {
+				return parsed, usageErr("missing %s", spec.name)
+			}
+			for _, value := range positionals {
+				err := validateValue(spec.kind, spec.choices, value)
+				if err != nil {
+					return parsed, usageErr("invalid %s: %v", spec.name, err)
+				}
+			}
+			parsed.lists[spec.name] = positionals
+			positionals = nil
+			break
+		}
+		if len(positionals) == 0 {
+			if !spec.optional {
+				return parsed, usageErr("missing %s", spec.name)
+			}
+			parsed.values[spec.name] = spec.def
+			continue
+		}
+		err := validateValue(spec.kind, spec.choices, positionals[0])
+		if err != nil {
+			return parsed, usageErr("invalid %s: %v", spec.name, err)
+		}
+		parsed.values[spec.name] = positionals[0]
+		positionals = positionals[1:]
+	}
+	if len(positionals) > 0 {
+		return parsed, usageErr("unexpected argument: %s", positionals[0])
+	}
+	return parsed, nil
+}
+
+func validateValue(kind valueKind, choices []string, value string) error {
+	switch kind {
+	case kindUUID:
+		_, err := uuid.Parse(value)
+		if err != nil {
+			return fmt.Errorf("%q is not an ID", value)
+		}
+	case kindChoice:
+		if !slices.Contains(choices, strings.ToLower(value)) {
+			return fmt.Errorf("%q is not one of %s", value, strings.Join(choices, ", "))
+		}
+	case kindInt:
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("%q is not a whole number", value)
+		}
+	case kindBarcode:
+		_, err := barcode.Normalize(value)
+		var invalid *barcode.Error
+		if errors.As(err, &invalid) {
+			return fmt.Errorf("%q: %s", value, invalid.Reason)
+		}
+	}
+	return nil
+}
+
+func findFlag(flags []flagSpec, name string) *flagSpec {
+	for i := range flags {
+		if flags[i].name == name {
+			return &flags[i]
+		}
+	}
+	return nil
+}
+
+// argsUsage describes the declared arguments and flags, such as
+// "<barcode> [shelf] [--yes]".
+func argsUsage(command cliCommand) string {
+	var parts []string
+	for _, spec := range command.args {
+		name := spec.name
+		if spec.variadic {
+			name += "..."
+		}
+		if spec.optional {
+			parts = append(parts, "["+name+"]")
+		} else {
+			parts = append(parts, "<"+name+">")
+		}
+	}
+	for _, spec := range command.flags {
+		if spec.kind == kindBool {
+			parts = append(parts, "[--"+spec.name+"]")
+		} else {
+			parts = append(parts, fmt.Sprintf("[--%s <%s>]", spec.name, spec.name))
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParseArgs(t *testing.T) {
+	command := cliCommand{
+		name: "add",
+		args: []argSpec{
+			{name: "movie ID", kind: kindUUID},
+			{name: "shelf", optional: true, def: "Top"},
+		},
+		flags: []flagSpec{
+			{name: "shelf"},
+			{name: "yes", kind: kindBool},
+		},
+	}
+
+	cases := []struct {
+		words    []string
+		expected map[string]string
+		flags    map[string]string
+		wantErr  bool
+	}{
+		{
+			words:    []string{"2b1e0f3c-94a5-4b59-9f3e-2f6f3c0d9c11"},
+			expected: map[string]string{"movie ID": "2b1e0f3c-94a5-4b59-9f3e-2f6f3c0d9c11", "shelf": "Top"},
+			flags:    map[string]string{},
+		},
+		{
+			words:    []string{"--yes", "2b1e0f3c-94a5-4b59-9f3e-2f6f3c0d9c11", "Bottom", "--shelf=Middle", "--format", "json"},
+			expected: map[string]string{"movie ID": "2b1e0f3c-94a5-4b59-9f3e-2f6f3c0d9c11", "shelf": "Bottom"},
+			flags:    map[string]string{"yes": "true", "shelf": "Middle", "format": "json"},
+		},
+		{words: []string{}, wantErr: true},
+		{words: []string{"not-an-id"}, wantErr: true},
+		{words: []string{"2b1e0f3c-94a5-4b59-9f3e-2f6f3c0d9c11", "Bottom", "extra"}, wantErr: true},
+		{words: []string{"2b1e0f3c-94a5-4b59-9f3e-2f6f3c0d9c11", "--unknown"}, wantErr: true},
+		{words: []string{"2b1e0f3c-94a5-4b59-9f3e-2f6f3c0d9c11", "--shelf"}, wantErr: true},
+		{words: []string{"2b1e0f3c-94a5-4b59-9f3e-2f6f3c0d9c11", "--format", "xml"}, wantErr: true},
+	}
+
+	for _, c := range cases {
+		args, err := parseArgs(command, "add", c.words)
+		if c.wantErr {
+			var usageErr *usageError
+			if !errors.As(err, &usageErr) {
+				t.Errorf("%v: expected a usage error, got %v", c.words, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", c.words, err)
+			continue
+		}
+		for name, value := range c.expected {
+			if args.arg(name) != value {
+				t.Errorf("%v: %s == %q, expected %q", c.words, name, args.arg(name), value)
+			}
+		}
+		for name, value := range c.flags {
+			if args.flag(name) != value {
+				t.Errorf("%v: --%s == %q, expected %q", c.words, name, args.flag(name), value)
+			}
+		}
+	}
+}
+
+func TestParseArgsVariadic(t *testing.T) {
+	command := cliCommand{
+		name: "prompt",
+		args: []argSpec{{name: "template", variadic: true}},
+	}
+
+	args, err := parseArgs(command, "set prompt", []string{"{{.User}}", ">", "--", "--yes"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"{{.User}}", ">", "--yes"}
+	if !slices.Equal(args.list("template"), expected) {
+		t.Errorf("template == %v, expected %v", args.list("template"), expected)
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+)
+
+// runOnce runs a single command given on the command line and returns the
+// exit code for its result.
+func runOnce(session *digitalshelfapi.Session, args []string) int {
+	err := runCommand(session, normalizeWords(args))
+	if errors.Is(err, errExit) {
+		return exitOK
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, formatError(err))
+	}
+	return exitCode(err)
+}
+
+// runScript runs commands read from stdin, one per line, stopping at the
+// first command that fails. Blank lines and lines starting with # are
+// skipped. Commands cannot prompt, as stdin holds the rest of the script.
+func runScript(session *digitalshelfapi.Session) int {
+	lineNumber := 0
+	for {
+		line, err := readLine("")
+		if errors.Is(err, io.EOF) {
+			return exitOK
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitError
+		}
+		lineNumber++
+
+		words := cleanInput(line)
+		if len(words) == 0 || strings.HasPrefix(words[0], "#") {
+			continue
+		}
+
+		runningScript = true
+		err = runCommand(session, words)
+		runningScript = false
+		if errors.Is(err, errExit) {
+			return exitOK
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "line %d: %s\n", lineNumber, formatError(err))
+			return exitCode(err)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+)
+
+// TestRunScriptDoesNotPrompt checks that a prompt in a script fails rather
+// than taking the next line of the script as its answer.
+func TestRunScriptDoesNotPrompt(t *testing.T) {
+	defer func(saved *bufio.Reader) { stdin = saved }(stdin)
+	stdin = bufio.NewReader(strings.NewReader("# sign in\nlogin\nluke@example.com\n"))
+
+	code := runScript(&digitalshelfapi.Session{})
+	if code != exitUsage {
+		t.Errorf("runScript() == %d, expected %d", code, exitUsage)
+	}
+	rest, _ := stdin.ReadString('\n')
+	if rest != "luke@example.com\n" {
+		t.Errorf("the prompt read the script: next line == %q", rest)
+	}
+	if runningScript {
+		t.Error("runningScript is still set after the script ended")
+	}
+}
+
+func TestRunScriptStopsAtExit(t *testing.T) {
+	defer func(saved *bufio.Reader) { stdin = saved }(stdin)
+	stdin = bufio.NewReader(strings.NewReader("exit\nlogin\n"))
+
+	code := runScript(&digitalshelfapi.Session{})
+	if code != exitOK {
+		t.Errorf("runScript() == %d, expected %d", code, exitOK)
+	}
+	rest, _ := stdin.ReadString('\n')
+	if rest != "login\n" {
+		t.Errorf("the script went on after exit: next line == %q", rest)
+	}
+}
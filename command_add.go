@@ -1,123 +1,397 @@
 package main
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
-	"os"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/Rodabaugh/digitalshelf-cli/internal/barcode"
 	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
 	"github.com/google/uuid"
 )
 
-func commandAdd(session *digitalshelfapi.Session, args ...string) error {
-	if len(args) < 2 {
-		return fmt.Errorf("please specify what you want to add and a barcode")
-	}
-
-	var shelfID uuid.UUID
-	var err error
-
-	if len(args) == 2 {
-		if session.CurrentShelf != uuid.Nil {
-			shelfID = session.CurrentShelf
-		} else {
-			fmt.Print("Please enter a shelf ID: ")
-			var shelfIDStr string
-			fmt.Scanln(&shelfIDStr)
-			shelfID, err = uuid.Parse(shelfIDStr)
-			if err != nil {
-				return fmt.Errorf("invalid shelf ID: %v", err)
-			}
-		}
-	} else {
-		shelfID, err = uuid.Parse(args[1])
+// itemType looks up, describes and adds one type of item by barcode.
+type itemType[T any] struct {
+	kind     string
+	lookup   func(...string) (T, error)
+	describe func(T)
+	details  func() (T, error)
+	barcode  func(*T, string)
+	add      func(uuid.UUID, T) error
+}
+
+func movieType(session *digitalshelfapi.Session) itemType[digitalshelfapi.Movie] {
+	return itemType[digitalshelfapi.Movie]{
+		kind:   "movie",
+		lookup: session.LookupMovieBarcode,
+		describe: func(movie digitalshelfapi.Movie) {
+			fmt.Printf("Title: %s\n", movie.Title)
+			fmt.Printf("Genre: %s\n", movie.Genre)
+			fmt.Printf("Actors: %s\n", movie.Actors)
+			fmt.Printf("Writer: %s\n", movie.Writer)
+			fmt.Printf("Director: %s\n", movie.Director)
+			fmt.Printf("Format: %s\n", movie.Format)
+			fmt.Printf("Release Date: %s\n", movie.ReleaseDate)
+		},
+		details: getMovieDetails,
+		barcode: func(movie *digitalshelfapi.Movie, barcode string) { movie.Barcode = barcode },
+		add:     session.AddMovie,
+	}
+}
+
+func showType(session *digitalshelfapi.Session) itemType[digitalshelfapi.Show] {
+	return itemType[digitalshelfapi.Show]{
+		kind:   "show",
+		lookup: session.LookupShowBarcode,
+		describe: func(show digitalshelfapi.Show) {
+			fmt.Printf("Title: %s\n", show.Title)
+			fmt.Printf("Season: %s\n", show.Season)
+			fmt.Printf("Genre: %s\n", show.Genre)
+			fmt.Printf("Actors: %s\n", show.Actors)
+			fmt.Printf("Writer: %s\n", show.Writer)
+			fmt.Printf("Director: %s\n", show.Director)
+			fmt.Printf("Format: %s\n", show.Format)
+			fmt.Printf("Release Date: %s\n", show.ReleaseDate)
+		},
+		details: getShowDetails,
+		barcode: func(show *digitalshelfapi.Show, barcode string) { show.Barcode = barcode },
+		add:     session.AddShow,
+	}
+}
+
+func bookType(session *digitalshelfapi.Session) itemType[digitalshelfapi.Book] {
+	return itemType[digitalshelfapi.Book]{
+		kind:   "book",
+		lookup: session.LookupBookBarcode,
+		describe: func(book digitalshelfapi.Book) {
+			fmt.Printf("Title: %s\n", book.Title)
+			fmt.Printf("Author: %s\n", book.Author)
+			fmt.Printf("Genre: %s\n", book.Genre)
+			fmt.Printf("Publication Date: %s\n", book.PublicationDate)
+		},
+		details: getBookDetails,
+		barcode: func(book *digitalshelfapi.Book, barcode string) { book.Barcode = barcode },
+		add:     session.AddBook,
+	}
+}
+
+func musicType(session *digitalshelfapi.Session) itemType[digitalshelfapi.Music] {
+	return itemType[digitalshelfapi.Music]{
+		kind:   "music",
+		lookup: session.LookupMusicBarcode,
+		describe: func(music digitalshelfapi.Music) {
+			fmt.Printf("Title: %s\n", music.Title)
+			fmt.Printf("Artist: %s\n", music.Artist)
+			fmt.Printf("Genre: %s\n", music.Genre)
+			fmt.Printf("Format: %s\n", music.Format)
+			fmt.Printf("Release Date: %s\n", music.ReleaseDate)
+		},
+		details: getMusicDetails,
+		barcode: func(music *digitalshelfapi.Music, barcode string) { music.Barcode = barcode },
+		add:     session.AddMusic,
+	}
+}
+
+// addItem returns the callback for an 'add' subcommand, which adds the
+// item with the given barcode to the chosen shelf.
+func addItem(add func(*digitalshelfapi.Session, uuid.UUID, string) error) func(*digitalshelfapi.Session, commandArgs) error {
+	return func(session *digitalshelfapi.Session, args commandArgs) error {
+		shelfID, err := chooseShelf(session, args)
 		if err != nil {
-			return fmt.Errorf("invalid shelf ID: %v", err)
+			return err
 		}
+		return add(session, shelfID, args.barcode("barcode"))
 	}
+}
+
+func addMovie(session *digitalshelfapi.Session, shelfID uuid.UUID, barcode string) error {
+	return addByBarcode(movieType(session), shelfID, barcode)
+}
+
+func addShow(session *digitalshelfapi.Session, shelfID uuid.UUID, barcode string) error {
+	return addByBarcode(showType(session), shelfID, barcode)
+}
 
-	switch args[0] {
-	case "movie":
-		return addMovie(session, shelfID, args[1])
-	case "show":
-		return addShow(session, shelfID, args[1])
-	case "book":
-		return addBook(session, shelfID, args[1])
-	case "music":
-		return addMusic(session, shelfID, args[1])
-	case "movie_bulk":
-		return benchmarkCreateMovie(session, shelfID, args[1])
-	default:
-		return fmt.Errorf("unknown add command: %s", args[0])
+func addBook(session *digitalshelfapi.Session, shelfID uuid.UUID, barcode string) error {
+	return addByBarcode(bookType(session), shelfID, barcode)
+}
+
+func addMusic(session *digitalshelfapi.Session, shelfID uuid.UUID, barcode string) error {
+	return addByBarcode(musicType(session), shelfID, barcode)
+}
+
+// addByBarcode looks up a barcode and adds what it finds, or asks for the
+// details when the barcode is unknown.
+func addByBarcode[T any](t itemType[T], shelfID uuid.UUID, code string) error {
+	item, err := t.lookup(code)
+	if errors.Is(err, digitalshelfapi.ErrNotFound) {
+		return addEntered(t, shelfID, code)
 	}
+	if err != nil {
+		return err
+	}
+	return addFound(t, shelfID, item)
 }
 
-func addMovie(session *digitalshelfapi.Session, shelfID uuid.UUID, barcode string) error {
-	movie, err := session.LookupMovieBarcode(barcode)
-	if err == nil {
-		fmt.Printf("Movie found!\n\n")
-		fmt.Printf("Title: %s\n", movie.Title)
-		fmt.Printf("Genre: %s\n", movie.Genre)
-		fmt.Printf("Actors: %s\n", movie.Actors)
-		fmt.Printf("Writer: %s\n", movie.Writer)
-		fmt.Printf("Director: %s\n", movie.Director)
-		fmt.Printf("Format: %s\n", movie.Format)
-		fmt.Printf("Release Date: %s\n", movie.ReleaseDate)
-		fmt.Println("Do you want to add this movie to the shelf? (y/n)")
-		var answer string
-		fmt.Scanln(&answer)
-		if answer != "y" {
-			return fmt.Errorf("movie not added to the shelf")
-		}
+// addFound shows an item a lookup found and adds it once confirmed.
+func addFound[T any](t itemType[T], shelfID uuid.UUID, item T) error {
+	fmt.Printf("%s found!\n\n", capitalize(t.kind))
+	t.describe(item)
+	add, err := confirm(fmt.Sprintf("Do you want to add this %s to the shelf?", t.kind))
+	if err != nil {
+		return err
 	}
+	if !add {
+		return fmt.Errorf("%s not added to the shelf", t.kind)
+	}
+	return saveItem(t, shelfID, item)
+}
 
-	if err != nil && err.Error() == "movie not found" {
-		fmt.Printf("This barcode does not exist in the database. Please enter it manually.\n\n")
-		movie, err = getMovieDetails()
+// addEntered asks for the details of an item whose barcode is unknown and
+// adds it.
+func addEntered[T any](t itemType[T], shelfID uuid.UUID, code string) error {
+	fmt.Printf("This barcode does not exist in the database. Please enter it manually.\n\n")
+	item, err := t.details()
+	if err != nil {
+		return fmt.Errorf("error getting %s details: %v", t.kind, err)
+	}
+	t.barcode(&item, code)
+	return saveItem(t, shelfID, item)
+}
+
+func saveItem[T any](t itemType[T], shelfID uuid.UUID, item T) error {
+	err := t.add(shelfID, item)
+	if err != nil {
+		return fmt.Errorf("error adding %s to shelf: %w", t.kind, err)
+	}
+	fmt.Printf("%s added successfully\n", capitalize(t.kind))
+	return nil
+}
+
+func capitalize(s string) string {
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// barcodeKind is an item type 'add <barcode>' can detect. The item found by
+// lookup is handed back to found, so it is not looked up twice.
+type barcodeKind struct {
+	kind   string
+	lookup func(*digitalshelfapi.Session, string) (any, error)
+	found  func(*digitalshelfapi.Session, uuid.UUID, any) error
+	enter  func(*digitalshelfapi.Session, uuid.UUID, string) error
+}
+
+var barcodeKinds = []barcodeKind{
+	detectable("movie", movieType),
+	detectable("show", showType),
+	detectable("book", bookType),
+	detectable("music", musicType),
+}
+
+func detectable[T any](kind string, newType func(*digitalshelfapi.Session) itemType[T]) barcodeKind {
+	return barcodeKind{
+		kind: kind,
+		lookup: func(session *digitalshelfapi.Session, code string) (any, error) {
+			return newType(session).lookup(code)
+		},
+		found: func(session *digitalshelfapi.Session, shelfID uuid.UUID, item any) error {
+			return addFound(newType(session), shelfID, item.(T))
+		},
+		enter: func(session *digitalshelfapi.Session, shelfID uuid.UUID, code string) error {
+			return addEntered(newType(session), shelfID, code)
+		},
+	}
+}
+
+// barcodeMatch is an item a barcode lookup found.
+type barcodeMatch struct {
+	barcodeKind
+	item any
+}
+
+func (m barcodeMatch) String() string {
+	_, title := itemIdentity(m.item)
+	return fmt.Sprintf("%s: %s", m.kind, title)
+}
+
+// barcodeHint returns the item type a barcode's prefix points to: ISBNs are
+// books and ISMNs are printed music. Other codes give no hint.
+func barcodeHint(code string) string {
+	switch {
+	case barcode.IsISBN(code):
+		return "book"
+	case barcode.IsISMN(code):
+		return "music"
+	}
+	return ""
+}
+
+// addDetected adds the item with the given barcode without being told its
+// type. Every type is looked up; the barcode's prefix decides which comes
+// first and breaks ties when there is no one to ask.
+func addDetected(session *digitalshelfapi.Session, args commandArgs) error {
+	shelfID, err := chooseShelf(session, args)
+	if err != nil {
+		return err
+	}
+	code := args.barcode("barcode")
+	hint := barcodeHint(code)
+
+	matches, err := lookupBarcode(session, code, hint)
+	if err != nil {
+		return err
+	}
+	if len(matches) > 0 {
+		match, err := chooseMatch(code, hint, matches)
 		if err != nil {
-			return fmt.Errorf("error getting movie details: %v", err)
+			return err
 		}
-		movie.Barcode = barcode
+		return match.found(session, shelfID, match.item)
 	}
 
-	err = session.AddMovie(shelfID, movie)
+	kinds := orderKinds(barcodeKinds, hint)
+	if hint != "" {
+		return kinds[0].enter(session, shelfID, code)
+	}
+	if !interactive {
+		return fmt.Errorf("barcode %s %w, use 'add movie', 'add show', 'add book' or 'add music' to enter it by hand", code, digitalshelfapi.ErrNotFound)
+	}
+	fmt.Printf("Barcode %s was not found. What is it?\n", code)
+	options := make([]string, 0, len(kinds))
+	for _, k := range kinds {
+		options = append(options, k.kind)
+	}
+	i, err := pick(options)
 	if err != nil {
-		return fmt.Errorf("error adding movie to shelf: %v", err)
+		return err
 	}
+	return kinds[i].enter(session, shelfID, code)
+}
 
-	return err
+// chooseMatch picks one of the items found for a barcode. The user chooses
+// when there is more than one, unless no one can be asked, in which case
+// the type the prefix points to wins.
+func chooseMatch(code, hint string, matches []barcodeMatch) (barcodeMatch, error) {
+	if len(matches) == 1 {
+		return matches[0], nil
+	}
+	if !interactive {
+		if matches[0].kind == hint {
+			return matches[0], nil
+		}
+		kinds := make([]string, 0, len(matches))
+		for _, match := range matches {
+			kinds = append(kinds, match.kind)
+		}
+		return barcodeMatch{}, fmt.Errorf("barcode %s matches more than one type of item (%s), please use 'add %s %s'", code, strings.Join(kinds, ", "), kinds[0], code)
+	}
+
+	fmt.Printf("Barcode %s matches more than one item:\n", code)
+	options := make([]string, 0, len(matches))
+	for _, match := range matches {
+		options = append(options, match.String())
+	}
+	i, err := pick(options)
+	if err != nil {
+		return barcodeMatch{}, err
+	}
+	return matches[i], nil
+}
+
+// lookupBarcode looks a barcode up as every type of item at the same time
+// and returns what was found, with the hinted type first.
+func lookupBarcode(session *digitalshelfapi.Session, code, hint string) ([]barcodeMatch, error) {
+	kinds := orderKinds(barcodeKinds, hint)
+	items := make([]any, len(kinds))
+	errs := make([]error, len(kinds))
+	var wg sync.WaitGroup
+	for i, k := range kinds {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			items[i], errs[i] = k.lookup(session, code)
+		}()
+	}
+	wg.Wait()
+
+	var matches []barcodeMatch
+	var failures []error
+	for i, k := range kinds {
+		switch {
+		case errs[i] == nil:
+			matches = append(matches, barcodeMatch{barcodeKind: k, item: items[i]})
+		case !errors.Is(errs[i], digitalshelfapi.ErrNotFound):
+			failures = append(failures, fmt.Errorf("error looking up %s: %w", k.kind, errs[i]))
+		}
+	}
+	if len(matches) == 0 && len(failures) > 0 {
+		return nil, errors.Join(failures...)
+	}
+	return matches, nil
+}
+
+// orderKinds moves the hinted type to the front, keeping the others in
+// order.
+func orderKinds(kinds []barcodeKind, hint string) []barcodeKind {
+	ordered := slices.Clone(kinds)
+	slices.SortStableFunc(ordered, func(a, b barcodeKind) int {
+		switch {
+		case a.kind == hint && b.kind != hint:
+			return -1
+		case b.kind == hint && a.kind != hint:
+			return 1
+		}
+		return 0
+	})
+	return ordered
+}
+
+// chooseShelf returns the shelf given as an argument or with --shelf, then
+// the current shelf, and asks for one as a last resort.
+func chooseShelf(session *digitalshelfapi.Session, args commandArgs) (uuid.UUID, error) {
+	shelf := args.arg("shelf")
+	if flag := args.flag("shelf"); flag != "" {
+		if shelf != "" && shelf != flag {
+			return uuid.Nil, usageErrorf("give the shelf either as an argument or with --shelf, not both")
+		}
+		shelf = flag
+	}
+	if shelf != "" {
+		return resolveShelf(session, shelf)
+	}
+	if session.CurrentShelf != uuid.Nil {
+		return session.CurrentShelf, nil
+	}
+
+	shelf, err := readLine("Please enter a shelf name or ID: ")
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return resolveShelf(session, shelf)
 }
 
 func getMovieDetails() (digitalshelfapi.Movie, error) {
-	scanner := bufio.NewScanner(os.Stdin)
 	fmt.Printf("Entering New Movie\n----------------------------\n")
 	var title, genre, actors, writer, director, releaseDateStr, format string
-	fmt.Print("Title: ")
-	scanner.Scan()
-	title = scanner.Text()
-	fmt.Print("Genre: ")
-	scanner.Scan()
-	genre = scanner.Text()
-	fmt.Print("Actors: ")
-	scanner.Scan()
-	actors = scanner.Text()
-	fmt.Print("Writer: ")
-	scanner.Scan()
-	writer = scanner.Text()
-	fmt.Print("Director: ")
-	scanner.Scan()
-	director = scanner.Text()
-	fmt.Print("Release Date (YYYY-MM-DD): ")
-	scanner.Scan()
-	releaseDateStr = scanner.Text()
+	err := promptFields([]promptField{
+		{"Title", &title},
+		{"Genre", &genre},
+		{"Actors", &actors},
+		{"Writer", &writer},
+		{"Director", &director},
+		{"Release Date (YYYY-MM-DD)", &releaseDateStr},
+		{"Format", &format},
+	})
+	if err != nil {
+		return digitalshelfapi.Movie{}, err
+	}
 	releaseDate, err := time.Parse("2006-01-02", releaseDateStr)
 	if err != nil {
 		return digitalshelfapi.Movie{}, fmt.Errorf("error parsing release date: %v", err)
 	}
-	fmt.Print("Format: ")
-	scanner.Scan()
-	format = scanner.Text()
 
 	movie := digitalshelfapi.Movie{
 		Title:       title,
@@ -132,75 +406,26 @@ func getMovieDetails() (digitalshelfapi.Movie, error) {
 	return movie, nil
 }
 
-func addShow(session *digitalshelfapi.Session, shelfID uuid.UUID, barcode string) error {
-	show, err := session.LookupShowBarcode(barcode)
-	if err == nil {
-		fmt.Printf("Show found!\n\n")
-		fmt.Printf("Title: %s\n", show.Title)
-		fmt.Printf("Season: %s\n", show.Season)
-		fmt.Printf("Genre: %s\n", show.Genre)
-		fmt.Printf("Actors: %s\n", show.Actors)
-		fmt.Printf("Writer: %s\n", show.Writer)
-		fmt.Printf("Director: %s\n", show.Director)
-		fmt.Printf("Format: %s\n", show.Format)
-		fmt.Printf("Release Date: %s\n", show.ReleaseDate)
-		fmt.Println("Do you want to add this show to the shelf? (y/n)")
-		var answer string
-		fmt.Scanln(&answer)
-		if answer != "y" {
-			return fmt.Errorf("show not added to the shelf")
-		}
-	}
-
-	if err != nil && err.Error() == "show not found" {
-		fmt.Printf("This barcode does not exist in the database. Please enter it manually.\n\n")
-		show, err = getShowDetails()
-		if err != nil {
-			return fmt.Errorf("error getting show details: %v", err)
-		}
-		show.Barcode = barcode
-	}
-
-	err = session.AddShow(shelfID, show)
-	if err != nil {
-		return fmt.Errorf("error adding show to shelf: %v", err)
-	}
-
-	return err
-}
-
 func getShowDetails() (digitalshelfapi.Show, error) {
-	scanner := bufio.NewScanner(os.Stdin)
 	fmt.Printf("Entering New Show\n----------------------------\n")
 	var title, season, genre, actors, writer, director, releaseDateStr, format string
-	fmt.Print("Title: ")
-	scanner.Scan()
-	title = scanner.Text()
-	fmt.Print("Season: ")
-	scanner.Scan()
-	season = scanner.Text()
-	fmt.Print("Genre: ")
-	scanner.Scan()
-	genre = scanner.Text()
-	fmt.Print("Actors: ")
-	scanner.Scan()
-	actors = scanner.Text()
-	fmt.Print("Writer: ")
-	scanner.Scan()
-	writer = scanner.Text()
-	fmt.Print("Director: ")
-	scanner.Scan()
-	director = scanner.Text()
-	fmt.Print("Release Date (YYYY-MM-DD): ")
-	scanner.Scan()
-	releaseDateStr = scanner.Text()
+	err := promptFields([]promptField{
+		{"Title", &title},
+		{"Season", &season},
+		{"Genre", &genre},
+		{"Actors", &actors},
+		{"Writer", &writer},
+		{"Director", &director},
+		{"Release Date (YYYY-MM-DD)", &releaseDateStr},
+		{"Format", &format},
+	})
+	if err != nil {
+		return digitalshelfapi.Show{}, err
+	}
 	releaseDate, err := time.Parse("2006-01-02", releaseDateStr)
 	if err != nil {
 		return digitalshelfapi.Show{}, fmt.Errorf("error parsing release date: %v", err)
 	}
-	fmt.Print("Format: ")
-	scanner.Scan()
-	format = scanner.Text()
 
 	show := digitalshelfapi.Show{
 		Title:       title,
@@ -216,55 +441,18 @@ func getShowDetails() (digitalshelfapi.Show, error) {
 	return show, nil
 }
 
-func addBook(session *digitalshelfapi.Session, shelfID uuid.UUID, barcode string) error {
-	book, err := session.LookupBookBarcode(barcode)
-	if err == nil {
-		fmt.Printf("Book found!\n\n")
-		fmt.Printf("Title: %s\n", book.Title)
-		fmt.Printf("Author: %s\n", book.Author)
-		fmt.Printf("Genre: %s\n", book.Genre)
-		fmt.Printf("Publication Date: %s\n", book.PublicationDate)
-		fmt.Println("Do you want to add this book to the shelf? (y/n)")
-		var answer string
-		fmt.Scanln(&answer)
-		if answer != "y" {
-			return fmt.Errorf("book not added to the shelf")
-		}
-	}
-
-	if err != nil && err.Error() == "book not found" {
-		fmt.Printf("This barcode does not exist in the database. Please enter it manually.\n\n")
-		book, err = getBookDetails()
-		if err != nil {
-			return fmt.Errorf("error getting book details: %v", err)
-		}
-		book.Barcode = barcode
-	}
-
-	err = session.AddBook(shelfID, book)
-	if err != nil {
-		return fmt.Errorf("error adding book to shelf: %v", err)
-	}
-
-	return err
-}
-
 func getBookDetails() (digitalshelfapi.Book, error) {
-	scanner := bufio.NewScanner(os.Stdin)
 	fmt.Printf("Entering New Book\n----------------------------\n")
 	var title, author, genre, publicationDateStr string
-	fmt.Print("Title: ")
-	scanner.Scan()
-	title = scanner.Text()
-	fmt.Print("Author: ")
-	scanner.Scan()
-	author = scanner.Text()
-	fmt.Print("Genre: ")
-	scanner.Scan()
-	genre = scanner.Text()
-	fmt.Print("Publication Date (YYYY-MM-DD): ")
-	scanner.Scan()
-	publicationDateStr = scanner.Text()
+	err := promptFields([]promptField{
+		{"Title", &title},
+		{"Author", &author},
+		{"Genre", &genre},
+		{"Publication Date (YYYY-MM-DD)", &publicationDateStr},
+	})
+	if err != nil {
+		return digitalshelfapi.Book{}, err
+	}
 	publicationDate, err := time.Parse("2006-01-02", publicationDateStr)
 	if err != nil {
 		return digitalshelfapi.Book{}, fmt.Errorf("error parsing publication date: %v", err)
@@ -280,59 +468,19 @@ func getBookDetails() (digitalshelfapi.Book, error) {
 	return book, nil
 }
 
-func addMusic(session *digitalshelfapi.Session, shelfID uuid.UUID, barcode string) error {
-	music, err := session.LookupMusicBarcode(barcode)
-	if err == nil {
-		fmt.Printf("Music found!\n\n")
-		fmt.Printf("Title: %s\n", music.Title)
-		fmt.Printf("Artist: %s\n", music.Artist)
-		fmt.Printf("Genre: %s\n", music.Genre)
-		fmt.Printf("Format: %s\n", music.Format)
-		fmt.Printf("Release Date: %s\n", music.ReleaseDate)
-		fmt.Println("Do you want to add this music to the shelf? (y/n)")
-		var answer string
-		fmt.Scanln(&answer)
-		if answer != "y" {
-			return fmt.Errorf("music not added to the shelf")
-		}
-	}
-
-	if err != nil && err.Error() == "music not found" {
-		fmt.Printf("This barcode does not exist in the database. Please enter it manually.\n\n")
-		music, err = getMusicDetails()
-		if err != nil {
-			return fmt.Errorf("error getting music details: %v", err)
-		}
-		music.Barcode = barcode
-	}
-
-	err = session.AddMusic(shelfID, music)
-	if err != nil {
-		return fmt.Errorf("error adding music to shelf: %v", err)
-	}
-
-	return err
-}
-
 func getMusicDetails() (digitalshelfapi.Music, error) {
-	scanner := bufio.NewScanner(os.Stdin)
 	fmt.Printf("Entering New Music\n----------------------------\n")
 	var title, artist, genre, format, releaseDateStr string
-	fmt.Print("Title: ")
-	scanner.Scan()
-	title = scanner.Text()
-	fmt.Print("Artist: ")
-	scanner.Scan()
-	artist = scanner.Text()
-	fmt.Print("Genre: ")
-	scanner.Scan()
-	genre = scanner.Text()
-	fmt.Print("Format: ")
-	scanner.Scan()
-	format = scanner.Text()
-	fmt.Print("Release Date (YYYY-MM-DD): ")
-	scanner.Scan()
-	releaseDateStr = scanner.Text()
+	err := promptFields([]promptField{
+		{"Title", &title},
+		{"Artist", &artist},
+		{"Genre", &genre},
+		{"Format", &format},
+		{"Release Date (YYYY-MM-DD)", &releaseDateStr},
+	})
+	if err != nil {
+		return digitalshelfapi.Music{}, err
+	}
 	releaseDate, err := time.Parse("2006-01-02", releaseDateStr)
 	if err != nil {
 		return digitalshelfapi.Music{}, fmt.Errorf("error parsing release date: %v", err)
@@ -363,15 +511,16 @@ func benchmarkCreateMovie(session *digitalshelfapi.Session, shelfID uuid.UUID, b
 		fmt.Printf("Writer: %s\n", movie.Writer)
 		fmt.Printf("Director: %s\n", movie.Director)
 		fmt.Printf("Release Date: %s\n", movie.ReleaseDate)
-		fmt.Println("Do you want to add this movie to the shelf? (y/n)")
-		var answer string
-		fmt.Scanln(&answer)
-		if answer != "y" {
+		add, err := confirm("Do you want to add this movie to the shelf?")
+		if err != nil {
+			return err
+		}
+		if !add {
 			return fmt.Errorf("movie not added to the shelf")
 		}
 	}
 
-	if err != nil && err.Error() == "movie not found" {
+	if errors.Is(err, digitalshelfapi.ErrNotFound) {
 		fmt.Printf("This barcode does not exist in the database. Please enter it manually.\n\n")
 		movie, err = getMovieDetails()
 		if err != nil {
@@ -379,13 +528,16 @@ func benchmarkCreateMovie(session *digitalshelfapi.Session, shelfID uuid.UUID, b
 		}
 		movie.Barcode = barcode
 	}
+	if err != nil {
+		return err
+	}
 
 	numberOfMovies := 500000
 	startTime := time.Now()
 	for i := 0; i < numberOfMovies; i++ {
 		err = session.AddMovie(shelfID, movie)
 		if err != nil {
-			return fmt.Errorf("error adding movie to shelf: %v", err)
+			return fmt.Errorf("error adding movie to shelf: %w", err)
 		}
 		fmt.Printf("Creating movie %v\n", i)
 	}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+	"github.com/google/uuid"
+)
+
+// barcodeServer answers barcode lookups for the given kinds and counts the
+// lookups and additions it sees.
+func barcodeServer(t *testing.T, found ...string) (*digitalshelfapi.Session, map[string]int) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/")
+		mu.Lock()
+		requests[r.Method+" "+path]++
+		mu.Unlock()
+
+		if r.Method == "POST" {
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		kind, _, _ := strings.Cut(strings.TrimPrefix(path, "search/"), "_barcodes/")
+		for _, k := range found {
+			if k == kind {
+				json.NewEncoder(w).Encode(map[string]string{"title": "Found " + kind})
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+
+	session := &digitalshelfapi.Session{
+		DSAPIClient: digitalshelfapi.NewClient(time.Second),
+		BaseURL:     server.URL + "/",
+	}
+	session.SetTokens("token", "refresh-token")
+	return session, requests
+}
+
+func matchKinds(matches []barcodeMatch) []string {
+	kinds := make([]string, 0, len(matches))
+	for _, match := range matches {
+		kinds = append(kinds, match.kind)
+	}
+	return kinds
+}
+
+func TestBarcodeHint(t *testing.T) {
+	cases := map[string]string{
+		"9780316769488": "book",
+		"9791234567896": "book",
+		"9790260000438": "music",
+		"883929106745":  "",
+		"4006381333931": "",
+	}
+	for code, expected := range cases {
+		if hint := barcodeHint(code); hint != expected {
+			t.Errorf("barcodeHint(%q) == %q, expected %q", code, hint, expected)
+		}
+	}
+}
+
+func TestLookupBarcodeTriesEveryKind(t *testing.T) {
+	cases := []struct {
+		code     string
+		found    []string
+		expected []string
+	}{
+		{code: "883929106745", found: []string{"movie", "book"}, expected: []string{"movie", "book"}},
+		{code: "9780316769488", found: []string{"music"}, expected: []string{"music"}},
+		{code: "9780316769488", found: []string{"movie", "book", "music"}, expected: []string{"book", "movie", "music"}},
+		{code: "9790260000438", found: []string{"book", "music"}, expected: []string{"music", "book"}},
+		{code: "4006381333931"},
+	}
+	for _, c := range cases {
+		session, requests := barcodeServer(t, c.found...)
+		matches, err := lookupBarcode(session, c.code, barcodeHint(c.code))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.code, err)
+			continue
+		}
+		kinds := matchKinds(matches)
+		if strings.Join(kinds, ",") != strings.Join(c.expected, ",") {
+			t.Errorf("%s found as %v: matches == %v, expected %v", c.code, c.found, kinds, c.expected)
+		}
+		for _, k := range barcodeKinds {
+			if n := requests["GET search/"+k.kind+"_barcodes/"+c.code]; n != 1 {
+				t.Errorf("%s: looked up as %s %d times, expected once", c.code, k.kind, n)
+			}
+		}
+	}
+}
+
+func TestChooseMatchWithoutPrompting(t *testing.T) {
+	defer func(saved bool) { interactive = saved }(interactive)
+	interactive = false
+
+	session, _ := barcodeServer(t, "movie", "book", "music")
+	for _, code := range []string{"9780316769488", "9790260000438"} {
+		hint := barcodeHint(code)
+		matches, err := lookupBarcode(session, code, hint)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", code, err)
+		}
+		match, err := chooseMatch(code, hint, matches)
+		if err != nil || match.kind != hint {
+			t.Errorf("%s: chose %q (%v), expected %q", code, match.kind, err, hint)
+		}
+	}
+
+	matches, err := lookupBarcode(session, "883929106745", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = chooseMatch("883929106745", "", matches)
+	if err == nil {
+		t.Fatal("expected an error for an ambiguous barcode")
+	}
+	var usageErr *usageError
+	if errors.As(err, &usageErr) {
+		t.Errorf("an ambiguous barcode is not a usage error: %v", err)
+	}
+}
+
+func TestAddDetectedLooksUpOnce(t *testing.T) {
+	defer func(saved bool) { assumeYes = saved }(assumeYes)
+	assumeYes = true
+
+	session, requests := barcodeServer(t, "movie")
+	args := commandArgs{values: map[string]string{"barcode": "883929106745"}}
+	session.CurrentShelf = uuid.MustParse("44444444-4444-4444-4444-444444444441")
+
+	err := addDetected(session, args)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := requests["GET search/movie_barcodes/883929106745"]; n != 1 {
+		t.Errorf("movie looked up %d times, expected once", n)
+	}
+	if n := requests["POST movies"]; n != 1 {
+		t.Errorf("movie added %d times, expected once", n)
+	}
+}
@@ -1,52 +1,56 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
-	"os"
+	"strings"
+	"unicode"
 
 	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
 )
 
-func commandRegister(session *digitalshelfapi.Session, _ ...string) error {
-	scanner := bufio.NewScanner(os.Stdin)
-	var name, email string
-
-	fmt.Print("Enter your name: ")
-	scanner.Scan()
-	name = scanner.Text()
-
-	fmt.Print("Enter your email: ")
-	scanner.Scan()
-	email = scanner.Text()
-
-	var newPassword, confirmPassword string
+const (
+	minPasswordLength = 8
+	// maxPasswordLength is the most bytes bcrypt will hash on the server.
+	maxPasswordLength = 72
+)
 
-	fmt.Print("Enter your new password: ")
-	scanner.Scan()
-	newPassword = scanner.Text()
+func commandRegister(session *digitalshelfapi.Session, args commandArgs) error {
+	name, err := readLine("Enter your name: ")
+	if err != nil {
+		return err
+	}
 
-	fmt.Print("Confrim your new password: ")
-	scanner.Scan()
-	confirmPassword = scanner.Text()
+	email, err := readLine("Enter your email: ")
+	if err != nil {
+		return err
+	}
 
-	if newPassword != confirmPassword {
-		return fmt.Errorf("passwords do not match")
+	newPassword, err := readNewPassword(email)
+	if err != nil {
+		return err
 	}
 
-	return session.CreateUser(name, email, newPassword)
+	_, err = session.CreateUser(name, email, newPassword)
+	if err != nil {
+		return err
+	}
+	fmt.Println("User created successfully")
+	fmt.Println("Please login with the new user credentials")
+	return nil
 }
 
-func commandLogin(session *digitalshelfapi.Session, args ...string) error {
-	var email, password string
-
-	fmt.Print("Enter your email: ")
-	fmt.Scanln(&email)
+func commandLogin(session *digitalshelfapi.Session, args commandArgs) error {
+	email, err := readLine("Enter your email: ")
+	if err != nil {
+		return err
+	}
 
-	fmt.Print("Enter your password: ")
-	fmt.Scanln(&password)
+	password, err := readPassword("Enter your password: ")
+	if err != nil {
+		return err
+	}
 
-	err := session.Authenticate(email, password)
+	err = session.Authenticate(strings.TrimSpace(email), password)
 	if err != nil {
 		return err
 	}
@@ -54,30 +58,98 @@ func commandLogin(session *digitalshelfapi.Session, args ...string) error {
 	return nil
 }
 
-func commandLogout(session *digitalshelfapi.Session, args ...string) error {
-	if len(args) == 0 {
-		return session.Logout()
+func commandLogout(session *digitalshelfapi.Session, args commandArgs) error {
+	err := session.Logout()
+	if err != nil {
+		return err
 	}
-	switch args[0] {
-	case "all":
-		return session.RevokeAllSessions()
-	default:
-		return fmt.Errorf("unknown logout command: %s", args[0])
+	fmt.Println("Logged out successfully")
+	return removeState()
+}
+
+func logoutAll(session *digitalshelfapi.Session, args commandArgs) error {
+	err := session.RevokeAllSessions()
+	if err != nil {
+		return err
 	}
+	fmt.Printf("All sessions revoked\nYou are now logged out\n")
+	return removeState()
 }
 
-func commandChangePassword(session *digitalshelfapi.Session, args ...string) error {
-	var newPassword, confirmPassword string
+func commandChangePassword(session *digitalshelfapi.Session, args commandArgs) error {
+	if !session.LoggedIn() {
+		return digitalshelfapi.ErrNotLoggedIn
+	}
 
-	fmt.Print("Enter your new password: ")
-	fmt.Scanln(&newPassword)
+	currentPassword, err := readPassword("Enter your current password: ")
+	if err != nil {
+		return err
+	}
+
+	newPassword, err := readNewPassword(session.User.Email)
+	if err != nil {
+		return err
+	}
+	if newPassword == currentPassword {
+		return fmt.Errorf("the new password must be different from the current one")
+	}
 
-	fmt.Print("Confrim your new password: ")
-	fmt.Scanln(&confirmPassword)
+	err = session.ChangePassword(currentPassword, newPassword)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Password changed successfully")
+	return nil
+}
+
+// readNewPassword asks for a new password twice and checks it against the
+// strength rules before anything is sent to the server.
+func readNewPassword(email string) (string, error) {
+	newPassword, err := readPassword("Enter your new password: ")
+	if err != nil {
+		return "", err
+	}
+	err = validatePasswordStrength(newPassword, email)
+	if err != nil {
+		return "", err
+	}
 
+	confirmPassword, err := readPassword("Confirm your new password: ")
+	if err != nil {
+		return "", err
+	}
 	if newPassword != confirmPassword {
-		return fmt.Errorf("passwords do not match")
+		return "", fmt.Errorf("passwords do not match")
 	}
+	return newPassword, nil
+}
 
-	return session.ChangePassword(newPassword)
+func validatePasswordStrength(password, email string) error {
+	if len(password) < minPasswordLength {
+		return fmt.Errorf("password must be at least %d characters long", minPasswordLength)
+	}
+	if len(password) > maxPasswordLength {
+		return fmt.Errorf("password must be at most %d bytes long", maxPasswordLength)
+	}
+	if strings.TrimSpace(password) != password {
+		return fmt.Errorf("password must not start or end with a space")
+	}
+
+	var hasLetter, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasLetter || !hasDigit {
+		return fmt.Errorf("password must contain at least one letter and one number")
+	}
+
+	if email != "" && strings.EqualFold(password, email) {
+		return fmt.Errorf("password must not be your email address")
+	}
+	return nil
 }
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidatePasswordStrength(t *testing.T) {
+	cases := []struct {
+		password string
+		email    string
+		message  string
+	}{
+		{password: "correct horse 42"},
+		{password: "short1", message: "at least"},
+		{password: strings.Repeat("a", maxPasswordLength) + "1", message: "at most"},
+		{password: " leading space 1", message: "start or end with a space"},
+		{password: "trailing space 1 ", message: "start or end with a space"},
+		{password: "no digits at all", message: "one letter and one number"},
+		{password: "1234567890", message: "one letter and one number"},
+		{password: "Luke1@Example.com", email: "luke1@example.com", message: "email address"},
+	}
+	for _, c := range cases {
+		err := validatePasswordStrength(c.password, c.email)
+		if c.message == "" {
+			if err != nil {
+				t.Errorf("validatePasswordStrength(%q) == %v, expected no error", c.password, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), c.message) {
+			t.Errorf("validatePasswordStrength(%q) == %v, expected an error about %q", c.password, err, c.message)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+)
+
+// completeCommandName is the hidden command shell completion scripts call
+// to ask for candidates.
+const completeCommandName = "__complete"
+
+// completionTimeout bounds each request made while completing, so a slow
+// server does not hang the shell.
+const completionTimeout = 2 * time.Second
+
+const bashCompletion = `# bash completion for {{name}}
+# Load it with: source <({{name}} completion bash)
+_{{func}}() {
+    local IFS=$'\n'
+    local cur=${COMP_WORDS[COMP_CWORD]}
+    local candidates
+    candidates=($({{name}} __complete -- "${COMP_WORDS[@]:1:COMP_CWORD-1}" "$cur" 2>/dev/null))
+    COMPREPLY=()
+    local candidate
+    for candidate in "${candidates[@]}"; do
+        COMPREPLY+=("$(printf '%q' "$candidate")")
+    done
+}
+complete -F _{{func}} {{name}}
+`
+
+const zshCompletion = `#compdef {{name}}
+# zsh completion for {{name}}
+# Load it with: source <({{name}} completion zsh)
+_{{func}}() {
+    local -a candidates
+    candidates=("${(@f)$({{name}} __complete -- "${(@)words[2,CURRENT-1]}" "${words[CURRENT]}" 2>/dev/null)}")
+    compadd -- "${(@)candidates:#}"
+}
+compdef _{{func}} {{name}}
+`
+
+const fishCompletion = `# fish completion for {{name}}
+# Load it with: {{name}} completion fish | source
+function __{{func}}_complete
+    set -l words (commandline -opc)
+    set -e words[1]
+    {{name}} __complete -- $words (commandline -ct) 2>/dev/null
+end
+complete -c {{name}} -f -a '(__{{func}}_complete)'
+`
+
+func completionBash(session *digitalshelfapi.Session, args commandArgs) error {
+	return printCompletionScript(bashCompletion)
+}
+
+func completionZsh(session *digitalshelfapi.Session, args commandArgs) error {
+	return printCompletionScript(zshCompletion)
+}
+
+func completionFish(session *digitalshelfapi.Session, args commandArgs) error {
+	return printCompletionScript(fishCompletion)
+}
+
+// printCompletionScript fills in the name the binary was run as, so the
+// script completes and calls back into the same command.
+func printCompletionScript(script string) error {
+	name := filepath.Base(os.Args[0])
+	funcName := strings.Map(func(r rune) rune {
+		if r == '-' || r == '.' {
+			return '_'
+		}
+		return r
+	}, name)
+	script = strings.ReplaceAll(script, "{{name}}", name)
+	script = strings.ReplaceAll(script, "{{func}}", funcName)
+	_, err := fmt.Print(script)
+	return err
+}
+
+// commandComplete prints the completion candidates for the last word, one
+// per line. Words arrive as the shell saw them, so quotes and escapes are
+// removed first. Global flags given before the command are skipped.
+func commandComplete(session *digitalshelfapi.Session, args commandArgs) error {
+	var words []string
+	for _, word := range args.list("words") {
+		unquoted, _, _ := splitInput(word)
+		words = append(words, strings.Join(unquoted, " "))
+	}
+	for len(words) > 1 && strings.HasPrefix(words[0], "-") {
+		if strings.Contains(words[0], "=") {
+			words = words[1:]
+		} else {
+			words = words[min(2, len(words)-1):]
+		}
+	}
+	if len(words) == 0 {
+		words = []string{""}
+	}
+
+	prefix := words[len(words)-1]
+	for _, candidate := range matchCandidates(completionCandidates(session, words[:len(words)-1], prefix), prefix) {
+		fmt.Println(candidate)
+	}
+	return nil
+}
+
+// completionProfile returns the profile chosen with --profile on the command
+// line being completed, so candidates come from that profile's saved
+// session. The word being completed is ignored.
+func completionProfile(words []string) string {
+	if len(words) > 0 && words[0] == "--" {
+		words = words[1:]
+	}
+	profile := ""
+	for i := 0; i < len(words)-1 && strings.HasPrefix(words[i], "-"); i++ {
+		unquoted, _, _ := splitInput(words[i])
+		name, value, hasValue := strings.Cut(strings.TrimLeft(strings.Join(unquoted, " "), "-"), "=")
+		if !hasValue {
+			i++
+			if i == len(words)-1 {
+				break
+			}
+			unquoted, _, _ = splitInput(words[i])
+			value = strings.Join(unquoted, " ")
+		}
+		if name == "profile" {
+			profile = value
+		}
+	}
+	return profile
+}
@@ -0,0 +1,22 @@
+package main
+
+import "testing"
+
+func TestCompletionProfile(t *testing.T) {
+	cases := []struct {
+		words    []string
+		expected string
+	}{
+		{words: []string{"--", "get", "shelf", ""}},
+		{words: []string{"--", "--profile", "work", "get", ""}, expected: "work"},
+		{words: []string{"--", "-profile=work", "get", ""}, expected: "work"},
+		{words: []string{"--", "--output", "json", "--profile", `"home lab"`, ""}, expected: "home lab"},
+		{words: []string{"--", "--profile", "wo"}},
+		{words: []string{"--", "get", "--profile", "work", ""}},
+	}
+	for _, c := range cases {
+		if profile := completionProfile(c.words); profile != c.expected {
+			t.Errorf("completionProfile(%q) == %q, expected %q", c.words, profile, c.expected)
+		}
+	}
+}
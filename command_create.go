@@ -6,24 +6,33 @@ import (
 	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
 )
 
-func commandCreate(session *digitalshelfapi.Session, args ...string) error {
-	if len(args) == 0 {
-		return fmt.Errorf("please specify what you want to create")
+func createLocation(session *digitalshelfapi.Session, args commandArgs) error {
+	location, err := session.CreateLocation(args.arg("name"))
+	if err != nil {
+		return err
 	}
-	if len(args) == 1 {
-		return fmt.Errorf("please specify what you want to create")
+	fmt.Printf("Location created successfully. Be sure to join it with its ID.\n New Location ID: %s\n", location.ID)
+	return nil
+}
+
+func createCase(session *digitalshelfapi.Session, args commandArgs) error {
+	c, err := session.CreateCase(args.arg("name"))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Case created successfully. Case ID: %s\n", c.ID)
+	return nil
+}
+
+func createShelf(session *digitalshelfapi.Session, args commandArgs) error {
+	caseID, err := resolveCase(session, args.arg("case"))
+	if err != nil {
+		return err
 	}
-	switch args[0] {
-	case "location":
-		return session.CreateLocation(args[1])
-	case "case":
-		return session.CreateCase(args[1])
-	case "shelf":
-		if len(args) < 3 {
-			return fmt.Errorf("please specify a shelf name and case ID")
-		}
-		return session.CreateShelf(args[1], args[2])
-	default:
-		return fmt.Errorf("unknown create command: %s", args[0])
+	shelf, err := session.CreateShelf(caseID.String(), args.arg("name"))
+	if err != nil {
+		return err
 	}
+	fmt.Printf("Shelf created successfully. Shelf ID: %s\n", shelf.ID)
+	return nil
 }
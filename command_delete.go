@@ -0,0 +1,318 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+	"github.com/google/uuid"
+)
+
+// itemDeleters delete one item of each type.
+var itemDeleters = map[string]func(*digitalshelfapi.Session, uuid.UUID) error{
+	"movie": (*digitalshelfapi.Session).DeleteMovie,
+	"show":  (*digitalshelfapi.Session).DeleteShow,
+	"book":  (*digitalshelfapi.Session).DeleteBook,
+	"music": (*digitalshelfapi.Session).DeleteMusic,
+}
+
+// containerContents is everything inside a location, case or shelf.
+type containerContents struct {
+	cases   []digitalshelfapi.Case
+	shelves []digitalshelfapi.Shelf
+	items   []itemMove
+}
+
+func (c containerContents) empty() bool {
+	return len(c.cases) == 0 && len(c.shelves) == 0 && len(c.items) == 0
+}
+
+// String describes the contents, such as "2 cases, 3 shelves and 1 item".
+func (c containerContents) String() string {
+	var parts []string
+	if len(c.cases) > 0 {
+		parts = append(parts, plural(len(c.cases), "case", "cases"))
+	}
+	if len(c.shelves) > 0 {
+		parts = append(parts, plural(len(c.shelves), "shelf", "shelves"))
+	}
+	if len(c.items) > 0 {
+		parts = append(parts, countItems(len(c.items)))
+	}
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	return strings.Join(parts[:len(parts)-1], ", ") + " and " + parts[len(parts)-1]
+}
+
+// containerDeletion describes how to delete one location, case or shelf.
+type containerDeletion struct {
+	id       uuid.UUID
+	kind     string
+	name     string
+	contents containerContents
+	// resolve finds the container of the same kind to move the contents to.
+	resolve func(ref string) (uuid.UUID, error)
+	// move moves the direct contents to another container.
+	move   func(to uuid.UUID) []error
+	remove func() error
+}
+
+func deleteLocation(session *digitalshelfapi.Session, args commandArgs) error {
+	id, err := resolveLocation(session, args.arg("location"))
+	if err != nil {
+		return err
+	}
+	location, err := session.GetLocation(id.String())
+	if err != nil {
+		return err
+	}
+	err = requireOwner(session, location, "delete")
+	if err != nil {
+		return err
+	}
+	cases, err := session.GetLocationCases(id)
+	if err != nil {
+		return err
+	}
+	contents, err := casesContents(session, cases)
+	if err != nil {
+		return err
+	}
+
+	return runDeletion(session, args, containerDeletion{
+		id:       id,
+		kind:     "location",
+		name:     location.Name,
+		contents: contents,
+		resolve: func(ref string) (uuid.UUID, error) {
+			return resolveLocation(session, ref)
+		},
+		move: func(to uuid.UUID) []error {
+			var failures []error
+			for _, c := range cases {
+				err := session.UpdateCase(c.ID, map[string]any{"location_id": to})
+				if err != nil {
+					failures = append(failures, fmt.Errorf("case %s: %w", c.Name, err))
+				}
+			}
+			return failures
+		},
+		remove: func() error {
+			return session.DeleteLocation(id)
+		},
+	})
+}
+
+func deleteCase(session *digitalshelfapi.Session, args commandArgs) error {
+	id, err := resolveCase(session, args.arg("case"))
+	if err != nil {
+		return err
+	}
+	c, err := session.GetCase(id.String())
+	if err != nil {
+		return err
+	}
+	contents, err := casesContents(session, []digitalshelfapi.Case{c})
+	if err != nil {
+		return err
+	}
+	contents.cases = nil
+
+	return runDeletion(session, args, containerDeletion{
+		id:       id,
+		kind:     "case",
+		name:     c.Name,
+		contents: contents,
+		resolve: func(ref string) (uuid.UUID, error) {
+			return resolveCase(session, ref)
+		},
+		move: func(to uuid.UUID) []error {
+			var failures []error
+			for _, shelf := range contents.shelves {
+				err := session.UpdateShelf(shelf.ID, map[string]any{"case_id": to})
+				if err != nil {
+					failures = append(failures, fmt.Errorf("shelf %s: %w", shelf.Name, err))
+				}
+			}
+			return failures
+		},
+		remove: func() error {
+			return session.DeleteCase(id)
+		},
+	})
+}
+
+func deleteShelf(session *digitalshelfapi.Session, args commandArgs) error {
+	shelf, err := findShelf(session, args.arg("shelf"))
+	if err != nil {
+		return err
+	}
+	items, _, err := shelfContents(session, shelf.ID)
+	if err != nil {
+		return err
+	}
+
+	return runDeletion(session, args, containerDeletion{
+		id:       shelf.ID,
+		kind:     "shelf",
+		name:     shelf.Name,
+		contents: containerContents{items: items},
+		resolve: func(ref string) (uuid.UUID, error) {
+			to, err := findShelf(session, ref)
+			return to.ID, err
+		},
+		move: func(to uuid.UUID) []error {
+			var failures []error
+			for _, item := range items {
+				err := shelfMovers[item.kind](session, item.id, to)
+				if err != nil {
+					failures = append(failures, fmt.Errorf("%s %q: %w", item.kind, item.title, err))
+				}
+			}
+			return failures
+		},
+		remove: func() error {
+			return session.DeleteShelf(shelf.ID)
+		},
+	})
+}
+
+// runDeletion shows what a container holds, then, if it is not empty, either
+// deletes everything in it or moves its contents elsewhere before deleting
+// the container itself. The container is kept if anything in it could not be
+// deleted or moved.
+func runDeletion(session *digitalshelfapi.Session, args commandArgs, d containerDeletion) error {
+	cascade := args.enabled("cascade")
+	moveTo := args.flag("move-to")
+	if cascade && moveTo != "" {
+		return usageErrorf("use either --cascade or --move-to, not both")
+	}
+
+	question := fmt.Sprintf("Delete %s %s?", d.kind, d.name)
+	var to uuid.UUID
+	if d.contents.empty() {
+		fmt.Printf("%s %s is empty.\n", strings.ToUpper(d.kind[:1])+d.kind[1:], d.name)
+	} else {
+		fmt.Printf("%s %s contains %s.\n", strings.ToUpper(d.kind[:1])+d.kind[1:], d.name, d.contents)
+		if !cascade && moveTo == "" {
+			if !interactive {
+				return usageErrorf("%s is not empty: use --cascade to delete everything in it, or --move-to <%s> to move its contents first", d.name, d.kind)
+			}
+			i, err := pick([]string{
+				"Delete everything in it",
+				fmt.Sprintf("Move its contents to another %s first", d.kind),
+			})
+			if err != nil {
+				return err
+			}
+			cascade = i == 0
+		}
+
+		if cascade {
+			question = fmt.Sprintf("Permanently delete %s %s and %s?", d.kind, d.name, d.contents)
+		} else {
+			var err error
+			if moveTo == "" {
+				moveTo, err = readLine(fmt.Sprintf("Move the contents to which %s? ", d.kind))
+				if err != nil {
+					return err
+				}
+			}
+			to, err = d.resolve(strings.TrimSpace(moveTo))
+			if err != nil {
+				return err
+			}
+			if to == d.id {
+				return usageErrorf("cannot move the contents of %s into itself", d.name)
+			}
+			question = fmt.Sprintf("Move the contents to %s and delete %s %s?", moveTo, d.kind, d.name)
+		}
+	}
+
+	ok, err := confirm(question)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%s not deleted", d.kind)
+	}
+
+	var failures []error
+	switch {
+	case d.contents.empty():
+	case cascade:
+		failures = cascadeDelete(session, d.contents)
+	default:
+		failures = d.move(to)
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%s %s was kept because not everything in it could be cleared:\n%w", d.kind, d.name, errors.Join(failures...))
+	}
+
+	err = d.remove()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Deleted %s %s\n", d.kind, d.name)
+	return nil
+}
+
+// cascadeDelete deletes items, then shelves, then cases, stopping before the
+// next level if anything fails.
+func cascadeDelete(session *digitalshelfapi.Session, contents containerContents) []error {
+	var failures []error
+	for _, item := range contents.items {
+		err := itemDeleters[item.kind](session, item.id)
+		if err != nil {
+			failures = append(failures, fmt.Errorf("%s %q: %w", item.kind, item.title, err))
+		}
+	}
+	if len(failures) > 0 {
+		return failures
+	}
+	for _, shelf := range contents.shelves {
+		err := session.DeleteShelf(shelf.ID)
+		if err != nil {
+			failures = append(failures, fmt.Errorf("shelf %s: %w", shelf.Name, err))
+		}
+	}
+	if len(failures) > 0 {
+		return failures
+	}
+	for _, c := range contents.cases {
+		err := session.DeleteCase(c.ID)
+		if err != nil {
+			failures = append(failures, fmt.Errorf("case %s: %w", c.Name, err))
+		}
+	}
+	return failures
+}
+
+// casesContents lists the given cases along with their shelves and items.
+func casesContents(session *digitalshelfapi.Session, cases []digitalshelfapi.Case) (containerContents, error) {
+	contents := containerContents{cases: cases}
+	for _, c := range cases {
+		shelves, err := session.GetCaseShelves(c.ID)
+		if err != nil {
+			return containerContents{}, err
+		}
+		contents.shelves = append(contents.shelves, shelves...)
+		for _, shelf := range shelves {
+			items, _, err := shelfContents(session, shelf.ID)
+			if err != nil {
+				return containerContents{}, err
+			}
+			contents.items = append(contents.items, items...)
+		}
+	}
+	return contents, nil
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return "1 " + one
+	}
+	return fmt.Sprintf("%d %s", n, many)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+	"github.com/google/uuid"
+)
+
+func TestDeleteLocationOutsideCurrentLocation(t *testing.T) {
+	defer func(saved bool) { assumeYes = saved }(assumeYes)
+	assumeYes = true
+
+	owner := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	home := uuid.MustParse("22222222-2222-2222-2222-222222222221")
+	away := uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	caseID := uuid.MustParse("33333333-3333-3333-3333-333333333331")
+	shelfID := uuid.MustParse("44444444-4444-4444-4444-444444444441")
+	movieID := uuid.MustParse("55555555-5555-5555-5555-555555555551")
+
+	var mu sync.Mutex
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/")
+		mu.Lock()
+		requests[r.Method+" "+path]++
+		mu.Unlock()
+
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		switch path {
+		case "locations/" + away.String():
+			json.NewEncoder(w).Encode(digitalshelfapi.Location{ID: away, Name: "Cabin", OwnerID: owner})
+		case "locations/" + away.String() + "/cases":
+			json.NewEncoder(w).Encode([]digitalshelfapi.Case{{ID: caseID, Name: "Hall Case", LocationID: away}})
+		case "cases/" + caseID.String():
+			json.NewEncoder(w).Encode(digitalshelfapi.Case{ID: caseID, Name: "Hall Case", LocationID: away})
+		case "cases/" + caseID.String() + "/shelves":
+			json.NewEncoder(w).Encode([]digitalshelfapi.Shelf{{ID: shelfID, Name: "Top Shelf", CaseID: caseID}})
+		case "shelves/" + shelfID.String() + "/movies":
+			json.NewEncoder(w).Encode([]digitalshelfapi.Movie{{ID: movieID, Title: "Heat", ShelfID: shelfID}})
+		default:
+			w.Write([]byte("[]"))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	session := &digitalshelfapi.Session{
+		DSAPIClient:     digitalshelfapi.NewClient(time.Second),
+		BaseURL:         server.URL + "/",
+		CurrentLocation: home,
+	}
+	session.User.ID = owner
+	session.SetTokens("token", "refresh-token")
+
+	args := commandArgs{
+		values: map[string]string{"location": away.String()},
+		flags:  map[string]string{"cascade": "true"},
+	}
+	_, err := captureStdout(t, func() error {
+		return deleteLocation(session, args)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, request := range []string{
+		"DELETE movies/" + movieID.String(),
+		"DELETE shelves/" + shelfID.String(),
+		"DELETE cases/" + caseID.String(),
+		"DELETE locations/" + away.String(),
+	} {
+		if requests[request] != 1 {
+			t.Errorf("%s sent %d times, expected once", request, requests[request])
+		}
+	}
+	if session.CurrentLocation != home {
+		t.Errorf("current location changed to %s", session.CurrentLocation)
+	}
+}
@@ -1,12 +1,15 @@
 package main
 
 import (
-	"os"
+	"errors"
 
 	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
 )
 
-func commandExit(session *digitalshelfapi.Session, args ...string) error {
-	os.Exit(0)
-	return nil
+// errExit is returned by 'exit' so the REPL or script stops and cleans up
+// on its way out, rather than the process ending mid-command.
+var errExit = errors.New("exit")
+
+func commandExit(session *digitalshelfapi.Session, args commandArgs) error {
+	return errExit
 }
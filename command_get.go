@@ -7,82 +7,174 @@ import (
 	"github.com/google/uuid"
 )
 
-func commandGet(session *digitalshelfapi.Session, args ...string) error {
-	if len(args) == 0 {
-		return fmt.Errorf("please specify what you want to get")
-	}
-	switch args[0] {
-	case "locations":
-		return session.GetUserLocations()
-	case "invites":
-		return session.GetUserInvites()
-	case "cases":
-		return session.GetCases()
-	case "shelves":
-		if len(args) < 2 {
-			return fmt.Errorf("please specify a case ID")
-		}
-		return session.GetShelves(args[1])
-	case "movies":
-		if len(args) < 2 {
-			return fmt.Errorf("please specify a shelf ID")
-		}
-		return session.GetMovies(args[1])
-	case "movie":
-		if len(args) < 2 {
-			return fmt.Errorf("please specify a movie ID")
-		}
-		return session.GetMovie(args[1])
-	case "shows":
-		if len(args) < 2 {
-			return fmt.Errorf("please specify a shelf ID")
-		}
-		return session.GetShows(args[1])
-	case "show":
-		if len(args) < 2 {
-			return fmt.Errorf("please specify a show ID")
-		}
-		return session.GetShow(args[1])
-	case "books":
-		if len(args) < 2 {
-			return fmt.Errorf("please specify a shelf ID")
-		}
-		return session.GetBooks(args[1])
-	case "book":
-		if len(args) < 2 {
-			return fmt.Errorf("please specify a book ID")
-		}
-		return session.GetBook(args[1])
-	case "music":
-		if len(args) < 2 {
-			return fmt.Errorf("please specify a shelf ID")
-		}
-		return session.GetMusic(args[1])
-	case "location":
-		return getLocation(session, args[1:]...)
-	default:
-		return fmt.Errorf("unknown get command: %s", args[0])
-	}
-}
-
-func getLocation(session *digitalshelfapi.Session, args ...string) error {
-	if len(args) == 0 {
-		return fmt.Errorf("please specify what you want to get for this location")
+func getLocations(session *digitalshelfapi.Session, args commandArgs) error {
+	locations, err := session.GetUserLocations()
+	if err != nil {
+		return err
 	}
+	return renderList(locations)
+}
+
+func getInvites(session *digitalshelfapi.Session, args commandArgs) error {
+	return listInvites(session, args)
+}
+
+func getCases(session *digitalshelfapi.Session, args commandArgs) error {
+	cases, err := session.GetCases()
+	if err != nil {
+		return err
+	}
+	return renderList(cases)
+}
+
+func getMembers(session *digitalshelfapi.Session, args commandArgs) error {
 	if session.CurrentLocation == uuid.Nil {
 		return fmt.Errorf("please set a location first")
 	}
+	members, err := session.GetLocationMembers(session.CurrentLocation)
+	if err != nil {
+		return err
+	}
+	return renderList(members)
+}
+
+func getShelves(session *digitalshelfapi.Session, args commandArgs) error {
+	caseID, err := resolveCase(session, args.arg("case"))
+	if err != nil {
+		return err
+	}
+	shelves, err := session.GetShelves(caseID.String())
+	if err != nil {
+		return err
+	}
+	return renderList(shelves)
+}
+
+func getMovies(session *digitalshelfapi.Session, args commandArgs) error {
+	shelfID, err := resolveShelf(session, args.arg("shelf"))
+	if err != nil {
+		return err
+	}
+	movies, err := session.GetMovies(shelfID.String())
+	if err != nil {
+		return err
+	}
+	return renderList(movies)
+}
+
+func getMovie(session *digitalshelfapi.Session, args commandArgs) error {
+	movie, err := session.GetMovie(args.arg("movie ID"))
+	if err != nil {
+		return err
+	}
+	return renderItem(movie)
+}
+
+func getShows(session *digitalshelfapi.Session, args commandArgs) error {
+	shelfID, err := resolveShelf(session, args.arg("shelf"))
+	if err != nil {
+		return err
+	}
+	shows, err := session.GetShows(shelfID.String())
+	if err != nil {
+		return err
+	}
+	return renderList(shows)
+}
 
-	switch args[0] {
-	case "movies":
-		return session.GetAllLocationMovies(session.CurrentLocation.String())
-	case "shows":
-		return session.GetAllLocationShows(session.CurrentLocation.String())
-	case "books":
-		return session.GetAllLocationBooks(session.CurrentLocation.String())
-	case "music":
-		return session.GetAllLocationMusic(session.CurrentLocation.String())
-	default:
-		return fmt.Errorf("unknown get command: %s", args[0])
+func getShow(session *digitalshelfapi.Session, args commandArgs) error {
+	show, err := session.GetShow(args.arg("show ID"))
+	if err != nil {
+		return err
+	}
+	return renderItem(show)
+}
+
+func getBooks(session *digitalshelfapi.Session, args commandArgs) error {
+	shelfID, err := resolveShelf(session, args.arg("shelf"))
+	if err != nil {
+		return err
+	}
+	books, err := session.GetBooks(shelfID.String())
+	if err != nil {
+		return err
+	}
+	return renderList(books)
+}
+
+func getBook(session *digitalshelfapi.Session, args commandArgs) error {
+	book, err := session.GetBook(args.arg("book ID"))
+	if err != nil {
+		return err
+	}
+	return renderItem(book)
+}
+
+func getMusic(session *digitalshelfapi.Session, args commandArgs) error {
+	shelfID, err := resolveShelf(session, args.arg("shelf"))
+	if err != nil {
+		return err
+	}
+	musicList, err := session.GetMusic(shelfID.String())
+	if err != nil {
+		return err
+	}
+	return renderList(musicList)
+}
+
+// currentLocation returns the current location, which the 'get location'
+// commands list items from.
+func currentLocation(session *digitalshelfapi.Session) (string, error) {
+	if session.CurrentLocation == uuid.Nil {
+		return "", fmt.Errorf("please set a location first")
+	}
+	return session.CurrentLocation.String(), nil
+}
+
+func getLocationMovies(session *digitalshelfapi.Session, args commandArgs) error {
+	locationID, err := currentLocation(session)
+	if err != nil {
+		return err
+	}
+	movies, err := session.GetAllLocationMovies(locationID)
+	if err != nil {
+		return err
+	}
+	return renderList(movies)
+}
+
+func getLocationShows(session *digitalshelfapi.Session, args commandArgs) error {
+	locationID, err := currentLocation(session)
+	if err != nil {
+		return err
+	}
+	shows, err := session.GetAllLocationShows(locationID)
+	if err != nil {
+		return err
+	}
+	return renderList(shows)
+}
+
+func getLocationBooks(session *digitalshelfapi.Session, args commandArgs) error {
+	locationID, err := currentLocation(session)
+	if err != nil {
+		return err
+	}
+	books, err := session.GetAllLocationBooks(locationID)
+	if err != nil {
+		return err
+	}
+	return renderList(books)
+}
+
+func getLocationMusic(session *digitalshelfapi.Session, args commandArgs) error {
+	locationID, err := currentLocation(session)
+	if err != nil {
+		return err
+	}
+	musicList, err := session.GetAllLocationMusic(locationID)
+	if err != nil {
+		return err
 	}
+	return renderList(musicList)
 }
@@ -2,16 +2,116 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
 )
 
-func commandHelp(session *digitalshelfapi.Session, args ...string) error {
-	availableCommands := getCommands()
-
-	for _, cmd := range availableCommands {
-		fmt.Printf("- %s : %s\n", cmd.name, cmd.description)
+func commandHelp(session *digitalshelfapi.Session, args commandArgs) error {
+	words := args.list("command")
+	if len(words) == 0 {
+		return writeCommandList(os.Stdout, permittedCommands(session))
 	}
 
+	command, ok := getCommands()[strings.ToLower(words[0])]
+	if !ok {
+		return usageErrorf("unknown command: %s", words[0])
+	}
+	path := command.name
+	for _, word := range words[1:] {
+		sub := findSubcommand(command.subcommands, word)
+		if sub == nil {
+			return usageErrorf("unknown %s command: %s", path, word)
+		}
+		command = *sub
+		path += " " + sub.name
+	}
+	writeCommandHelp(os.Stdout, path, command)
 	return nil
 }
+
+// writeCommandList lists every command by category, sorted by name.
+func writeCommandList(w io.Writer, commands map[string]cliCommand) error {
+	byCategory := map[string][]cliCommand{}
+	for _, command := range commands {
+		if command.hidden {
+			continue
+		}
+		byCategory[command.category] = append(byCategory[command.category], command)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for i, category := range commandCategories {
+		list := byCategory[category]
+		sort.Slice(list, func(a, b int) bool {
+			return list[a].name < list[b].name
+		})
+		if i > 0 {
+			fmt.Fprintln(tw)
+		}
+		fmt.Fprintf(tw, "%s:\n", category)
+		for _, command := range list {
+			fmt.Fprintf(tw, "  %s\t%s\n", command.name, command.description)
+		}
+	}
+	fmt.Fprintln(tw, "\nRun 'help <command>' for its usage, subcommands and examples.")
+	fmt.Fprintln(tw, "Every command also accepts --format <format> for its output, and --help.")
+	return tw.Flush()
+}
+
+// writeCommandHelp describes one command or subcommand, where path is the
+// words used to run it.
+func writeCommandHelp(w io.Writer, path string, command cliCommand) {
+	fmt.Fprintf(w, "Usage: %s\n", commandUsage(path, command))
+	if command.description != "" {
+		fmt.Fprintf(w, "\n%s\n", command.description)
+	}
+
+	if len(command.subcommands) > 0 {
+		fmt.Fprintln(w, "\nSubcommands:")
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		for _, sub := range command.subcommands {
+			fmt.Fprintf(tw, "  %s\t%s\n", commandUsage(sub.name, sub), sub.description)
+		}
+		tw.Flush()
+	}
+
+	if len(command.flags) > 0 {
+		fmt.Fprintln(w, "\nFlags:")
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		for _, flag := range command.flags {
+			name := "--" + flag.name
+			if flag.kind != kindBool {
+				name += " <" + flag.name + ">"
+			}
+			fmt.Fprintf(tw, "  %s\t%s\n", name, flag.description)
+		}
+		tw.Flush()
+	}
+
+	if len(command.examples) > 0 {
+		fmt.Fprintln(w, "\nExamples:")
+		for _, example := range command.examples {
+			fmt.Fprintf(w, "  %s\n", example)
+		}
+	}
+}
+
+// commandUsage is the usage line of a command: its path followed by its
+// arguments and flags, or a placeholder for its subcommands.
+func commandUsage(path string, command cliCommand) string {
+	usage := argsUsage(command)
+	switch {
+	case usage != "":
+		return path + " " + usage
+	case len(command.subcommands) > 0 && command.callback != nil:
+		return path + " [subcommand]"
+	case len(command.subcommands) > 0:
+		return path + " <subcommand>"
+	}
+	return path
+}
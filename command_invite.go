@@ -2,14 +2,117 @@ package main
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+	"github.com/google/uuid"
 )
 
-func commandInvite(session *digitalshelfapi.Session, args ...string) error {
-	if len(args) == 0 {
-		return fmt.Errorf("please specify a user ID")
+func commandInvite(session *digitalshelfapi.Session, args commandArgs) error {
+	err := session.InviteUser(args.arg("user ID"))
+	if err != nil {
+		return err
 	}
+	fmt.Println("User invited successfully")
+	return nil
+}
+
+// pendingInvite is an invite as listed by 'invites', numbered so it can be
+// accepted or declined by number.
+type pendingInvite struct {
+	Number       int       `json:"number"`
+	LocationID   uuid.UUID `json:"location_id"`
+	LocationName string    `json:"location_name"`
+	OwnerID      uuid.UUID `json:"owner_id"`
+	InvitedAt    time.Time `json:"invited_at"`
+}
+
+// pendingInvites returns the user's invites, oldest first, so the numbers
+// stay the same from one listing to the next.
+func pendingInvites(session *digitalshelfapi.Session) ([]pendingInvite, error) {
+	invites, err := session.GetUserInvites()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(invites, func(i, j int) bool {
+		return invites[i].InvitedAt.Before(invites[j].InvitedAt)
+	})
+	pending := make([]pendingInvite, 0, len(invites))
+	for i, invite := range invites {
+		pending = append(pending, pendingInvite{
+			Number:       i + 1,
+			LocationID:   invite.LocationID,
+			LocationName: invite.LocationName,
+			OwnerID:      invite.OwnerID,
+			InvitedAt:    invite.InvitedAt,
+		})
+	}
+	return pending, nil
+}
+
+// findInvite returns the invite with the given number from 'invites', or to
+// the location with the given name.
+func findInvite(session *digitalshelfapi.Session, ref string) (pendingInvite, error) {
+	invites, err := pendingInvites(session)
+	if err != nil {
+		return pendingInvite{}, err
+	}
+	if len(invites) == 0 {
+		return pendingInvite{}, fmt.Errorf("you have no pending invites")
+	}
+	if n, err := strconv.Atoi(ref); err == nil {
+		if n < 1 || n > len(invites) {
+			return pendingInvite{}, usageErrorf("there is no invite %d, run 'invites' to list them", n)
+		}
+		return invites[n-1], nil
+	}
+	for _, invite := range invites {
+		if strings.EqualFold(invite.LocationName, ref) {
+			return invite, nil
+		}
+	}
+	return pendingInvite{}, fmt.Errorf("no invite to a location named %q", ref)
+}
+
+func listInvites(session *digitalshelfapi.Session, args commandArgs) error {
+	invites, err := pendingInvites(session)
+	if err != nil {
+		return err
+	}
+	if len(invites) == 0 && currentOutput == formatTable {
+		fmt.Println("You have no pending invites")
+		return nil
+	}
+	return renderList(invites)
+}
+
+func inviteAccept(session *digitalshelfapi.Session, args commandArgs) error {
+	invite, err := findInvite(session, args.arg("invite"))
+	if err != nil {
+		return err
+	}
+	return joinLocation(session, invite.LocationID)
+}
 
-	return session.InviteUser(args[0])
+func inviteDecline(session *digitalshelfapi.Session, args commandArgs) error {
+	invite, err := findInvite(session, args.arg("invite"))
+	if err != nil {
+		return err
+	}
+	ok, err := confirm(fmt.Sprintf("Decline the invite to %s?", invite.LocationName))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("invite not declined")
+	}
+	err = session.DeclineInvite(invite.LocationID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Declined the invite to %s\n", invite.LocationName)
+	return nil
 }
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+)
+
+func TestListInvitesWhenEmpty(t *testing.T) {
+	defer func(saved outputFormat) { currentOutput = saved }(currentOutput)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("[]"))
+	}))
+	t.Cleanup(server.Close)
+	session := &digitalshelfapi.Session{
+		DSAPIClient: digitalshelfapi.NewClient(time.Second),
+		BaseURL:     server.URL + "/",
+	}
+	session.SetTokens("token", "refresh-token")
+
+	cases := map[outputFormat]string{
+		formatTable: "You have no pending invites\n",
+		formatJSON:  "[]\n",
+		formatJSONL: "",
+	}
+	for format, expected := range cases {
+		currentOutput = format
+		out, err := captureStdout(t, func() error { return listInvites(session, commandArgs{}) })
+		if err != nil || out != expected {
+			t.Errorf("%s: listInvites printed %q (%v), expected %q", format, out, err, expected)
+		}
+	}
+}
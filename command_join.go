@@ -4,12 +4,42 @@ import (
 	"fmt"
 
 	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+	"github.com/google/uuid"
 )
 
-func commandJoin(session *digitalshelfapi.Session, args ...string) error {
-	if len(args) == 0 {
-		return fmt.Errorf("please specify a location ID")
+func commandJoin(session *digitalshelfapi.Session, args commandArgs) error {
+	locationID, err := resolveInvite(session, args.arg("location"))
+	if err != nil {
+		return err
 	}
+	return joinLocation(session, locationID)
+}
+
+// joinLocation joins a location the user was invited to, then offers to
+// make it the current location. The location is named as the server knows
+// it, not as it was typed.
+func joinLocation(session *digitalshelfapi.Session, locationID uuid.UUID) error {
+	err := session.JoinLocaion(locationID.String())
+	if err != nil {
+		return err
+	}
+	fmt.Println("Joined successfully")
 
-	return session.JoinLocaion(args[0])
+	if !interactive && !assumeYes {
+		return nil
+	}
+	location, err := session.GetLocation(locationID.String())
+	if err != nil {
+		return err
+	}
+	ok, err := confirm(fmt.Sprintf("Make %s your current location?", location.Name))
+	if err != nil || !ok {
+		return err
+	}
+	location, err = session.SetCurrentLocation(locationID.String())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Location set to: %s\n", location.Name)
+	return nil
 }
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+	"github.com/google/uuid"
+)
+
+// shelfMovers move one item of each type to another shelf. The shelf is not
+// checked again for every item, so callers check it once with findShelf.
+var shelfMovers = map[string]func(*digitalshelfapi.Session, uuid.UUID, uuid.UUID) error{
+	"movie": moveWith((*digitalshelfapi.Session).UpdateMovie),
+	"show":  moveWith((*digitalshelfapi.Session).UpdateShow),
+	"book":  moveWith((*digitalshelfapi.Session).UpdateBook),
+	"music": moveWith((*digitalshelfapi.Session).UpdateMusic),
+}
+
+func moveWith(update func(*digitalshelfapi.Session, uuid.UUID, map[string]any) error) func(*digitalshelfapi.Session, uuid.UUID, uuid.UUID) error {
+	return func(session *digitalshelfapi.Session, id, shelfID uuid.UUID) error {
+		return update(session, id, map[string]any{"shelf_id": shelfID})
+	}
+}
+
+// itemMove is an item to be moved to another shelf.
+type itemMove struct {
+	kind  string
+	id    uuid.UUID
+	title string
+}
+
+func moveMovie(session *digitalshelfapi.Session, args commandArgs) error {
+	return moveItem(session, args, "movie")
+}
+
+func moveShow(session *digitalshelfapi.Session, args commandArgs) error {
+	return moveItem(session, args, "show")
+}
+
+func moveBook(session *digitalshelfapi.Session, args commandArgs) error {
+	return moveItem(session, args, "book")
+}
+
+func moveMusic(session *digitalshelfapi.Session, args commandArgs) error {
+	return moveItem(session, args, "music")
+}
+
+func moveItem(session *digitalshelfapi.Session, args commandArgs, kind string) error {
+	shelf, err := findShelf(session, args.arg("shelf"))
+	if err != nil {
+		return err
+	}
+	err = shelfMovers[kind](session, args.id("id"), shelf.ID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s moved to %s\n", strings.ToUpper(kind[:1])+kind[1:], shelf.Name)
+	return nil
+}
+
+// moveShelfContents moves every item on one shelf to another. It carries on
+// past items that fail to move and reports them at the end.
+func moveShelfContents(session *digitalshelfapi.Session, args commandArgs) error {
+	from, err := findShelf(session, args.arg("from"))
+	if err != nil {
+		return err
+	}
+	to, err := findShelf(session, args.arg("to"))
+	if err != nil {
+		return err
+	}
+	if from.ID == to.ID {
+		return usageErrorf("the shelves to move from and to are the same")
+	}
+
+	moves, counts, err := shelfContents(session, from.ID)
+	if err != nil {
+		return err
+	}
+	if len(moves) == 0 {
+		fmt.Printf("%s is empty\n", from.Name)
+		return nil
+	}
+
+	fmt.Printf("%s has %s.\n", from.Name, counts)
+	ok, err := confirm(fmt.Sprintf("Move %s from %s to %s?", countItems(len(moves)), from.Name, to.Name))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("nothing moved")
+	}
+
+	moved := 0
+	var failures []error
+	for i, move := range moves {
+		err := shelfMovers[move.kind](session, move.id, to.ID)
+		if err != nil {
+			fmt.Printf("[%d/%d] Could not move %s %q\n", i+1, len(moves), move.kind, move.title)
+			failures = append(failures, fmt.Errorf("%s %s: %w", move.kind, move.id, err))
+			continue
+		}
+		fmt.Printf("[%d/%d] Moved %s %q\n", i+1, len(moves), move.kind, move.title)
+		moved++
+	}
+
+	fmt.Printf("Moved %d of %s from %s to %s\n", moved, countItems(len(moves)), from.Name, to.Name)
+	if len(failures) > 0 {
+		return fmt.Errorf("could not move %s:\n%w", countItems(len(failures)), errors.Join(failures...))
+	}
+	return nil
+}
+
+// findShelf resolves a shelf by name or ID and checks that it exists in the
+// current location. Shelves found by name always are, but an ID may point
+// anywhere.
+func findShelf(session *digitalshelfapi.Session, ref string) (digitalshelfapi.Shelf, error) {
+	id, err := resolveShelf(session, ref)
+	if err != nil {
+		return digitalshelfapi.Shelf{}, err
+	}
+	shelf, err := session.GetShelf(id.String())
+	if err != nil {
+		return digitalshelfapi.Shelf{}, err
+	}
+	c, err := session.GetCase(shelf.CaseID.String())
+	if err != nil {
+		return digitalshelfapi.Shelf{}, err
+	}
+	if c.LocationID != session.CurrentLocation {
+		return digitalshelfapi.Shelf{}, fmt.Errorf("shelf %s is not in the current location", shelf.Name)
+	}
+	return shelf, nil
+}
+
+// shelfContents lists every item on a shelf, along with how many there are
+// of each type, such as "2 movies, 1 book".
+func shelfContents(session *digitalshelfapi.Session, shelfID uuid.UUID) ([]itemMove, string, error) {
+	var moves []itemMove
+	var counts []string
+	add := func(kind, kinds string, items []any) {
+		for _, item := range items {
+			id, title := itemIdentity(item)
+			moves = append(moves, itemMove{kind: kind, id: id, title: title})
+		}
+		if len(items) > 0 {
+			counts = append(counts, plural(len(items), kind, kinds))
+		}
+	}
+
+	movies, err := session.GetMovies(shelfID.String())
+	if err != nil {
+		return nil, "", err
+	}
+	add("movie", "movies", toAny(movies))
+	shows, err := session.GetShows(shelfID.String())
+	if err != nil {
+		return nil, "", err
+	}
+	add("show", "shows", toAny(shows))
+	books, err := session.GetBooks(shelfID.String())
+	if err != nil {
+		return nil, "", err
+	}
+	add("book", "books", toAny(books))
+	music, err := session.GetMusic(shelfID.String())
+	if err != nil {
+		return nil, "", err
+	}
+	add("music", "music", toAny(music))
+
+	return moves, strings.Join(counts, ", "), nil
+}
+
+func toAny[T any](items []T) []any {
+	values := make([]any, 0, len(items))
+	for _, item := range items {
+		values = append(values, item)
+	}
+	return values
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+	"github.com/google/uuid"
+)
+
+func profileList(session *digitalshelfapi.Session, args commandArgs) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	return listProfiles(cfg)
+}
+
+func profileUse(session *digitalshelfapi.Session, args commandArgs) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	return useProfile(session, cfg, args.arg("name"))
+}
+
+func profileAdd(session *digitalshelfapi.Session, args commandArgs) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	return addProfile(cfg, args.arg("name"), args.arg("url"), args.arg("platform"), args.id("default location"))
+}
+
+func profileRemove(session *digitalshelfapi.Session, args commandArgs) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	return removeProfile(cfg, args.arg("name"))
+}
+
+func listProfiles(cfg config) error {
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	if _, ok := cfg.Profiles[defaultProfileName]; !ok {
+		if _, err := profileFromEnv(); err == nil {
+			names = append(names, defaultProfileName)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		marker := " "
+		if name == activeProfile {
+			marker = "*"
+		}
+		prof, ok := cfg.Profiles[name]
+		if !ok {
+			fmt.Printf("%s %s (from environment)\n", marker, name)
+			continue
+		}
+		fmt.Printf("%s %s: %s [%s]", marker, name, prof.BaseURL, prof.Platform)
+		if prof.DefaultLocation != uuid.Nil {
+			fmt.Printf(" default location %s", prof.DefaultLocation)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+func useProfile(session *digitalshelfapi.Session, cfg config, name string) error {
+	err := validateProfileName(name)
+	if err != nil {
+		return err
+	}
+	prof, ok := cfg.Profiles[name]
+	if !ok && name == defaultProfileName {
+		var err error
+		prof, err = profileFromEnv()
+		ok = err == nil
+	}
+	if !ok {
+		return fmt.Errorf("unknown profile: %s", name)
+	}
+
+	err = persistSession(session)
+	if err != nil {
+		return err
+	}
+
+	cfg.CurrentProfile = name
+	err = saveConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	startSession(session, name, prof)
+	fmt.Printf("Now using profile %s\n", name)
+	return nil
+}
+
+func addProfile(cfg config, name, baseURL, platform string, defaultLocation uuid.UUID) error {
+	err := validateProfileName(name)
+	if err != nil {
+		return err
+	}
+	if _, ok := cfg.Profiles[name]; ok {
+		return fmt.Errorf("profile %s already exists", name)
+	}
+
+	prof, err := newProfile(baseURL, platform)
+	if err != nil {
+		return err
+	}
+	prof.DefaultLocation = defaultLocation
+
+	cfg.Profiles[name] = prof
+	err = saveConfig(cfg)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Profile %s added. Switch to it with 'profile use %s'\n", name, name)
+	return nil
+}
+
+func removeProfile(cfg config, name string) error {
+	err := validateProfileName(name)
+	if err != nil {
+		return err
+	}
+	if name == activeProfile {
+		return fmt.Errorf("cannot remove the profile in use, switch to another profile first")
+	}
+	if _, ok := cfg.Profiles[name]; !ok {
+		return fmt.Errorf("unknown profile: %s", name)
+	}
+
+	delete(cfg.Profiles, name)
+	if cfg.CurrentProfile == name {
+		cfg.CurrentProfile = ""
+	}
+	err = saveConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	path, err := stateFilePath(name)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	fmt.Printf("Profile %s removed\n", name)
+	return nil
+}
@@ -1,27 +1,210 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"slices"
 
 	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+	"github.com/google/uuid"
 )
 
-func commandRemove(session *digitalshelfapi.Session, args ...string) error {
-	if len(args) == 0 {
-		return fmt.Errorf("please specify what you want to remove")
+func removeMember(session *digitalshelfapi.Session, args commandArgs) error {
+	err := session.RemoveLocationMember(args.arg("user ID"))
+	if err != nil {
+		return err
 	}
-	switch args[0] {
-	case "member":
-		if len(args) < 2 {
-			return fmt.Errorf("please specify a user ID")
+	fmt.Println("Member removed successfully")
+	return nil
+}
+
+func removeInvite(session *digitalshelfapi.Session, args commandArgs) error {
+	err := session.RemoveUserInvite(args.arg("user ID"))
+	if err != nil {
+		return err
+	}
+	fmt.Println("Invite removed successfully")
+	return nil
+}
+
+func removeMovies(session *digitalshelfapi.Session, args commandArgs) error {
+	return removeItems(session, args, "movie", session.GetMovie, session.GetMovies, session.DeleteMovie)
+}
+
+func removeShows(session *digitalshelfapi.Session, args commandArgs) error {
+	return removeItems(session, args, "show", session.GetShow, session.GetShows, session.DeleteShow)
+}
+
+func removeBooks(session *digitalshelfapi.Session, args commandArgs) error {
+	return removeItems(session, args, "book", session.GetBook, session.GetBooks, session.DeleteBook)
+}
+
+func removeMusic(session *digitalshelfapi.Session, args commandArgs) error {
+	return removeItems(session, args, "music", session.GetMusicByID, session.GetMusic, session.DeleteMusic)
+}
+
+// removeItems deletes the items with the given IDs or, with none, the items
+// picked from a shelf listing. Each item is shown before anything is
+// deleted, and deleted items go to the trash.
+func removeItems[T any](
+	session *digitalshelfapi.Session,
+	args commandArgs,
+	kind string,
+	get func(...string) (T, error),
+	list func(...string) ([]T, error),
+	remove func(uuid.UUID) error,
+) error {
+	var items []T
+	ids := uniqueIDs(args.list("ids"))
+	if len(ids) > 0 {
+		for _, id := range ids {
+			item, err := get(id)
+			if err != nil {
+				return err
+			}
+			items = append(items, item)
+		}
+	} else {
+		if !interactive {
+			return usageErrorf("please specify the ID of each %s to remove", kind)
 		}
-		return session.RemoveLocationMember(args[1])
-	case "invite":
-		if len(args) < 2 {
-			return fmt.Errorf("please specify a user ID")
+		var err error
+		items, err = selectItems(session, args, list)
+		if err != nil {
+			return err
 		}
-		return session.RemoveUserInvite(args[1])
-	default:
-		return fmt.Errorf("unknown remove command: %s", args[0])
+		if len(items) == 0 {
+			fmt.Println("Nothing to remove")
+			return nil
+		}
+	}
+
+	err := writeList(os.Stdout, formatTable, items)
+	if err != nil {
+		return err
+	}
+	ok, err := confirm(fmt.Sprintf("Remove %s?", countItems(len(items))))
+	if err != nil {
+		return err
 	}
+	if !ok {
+		return fmt.Errorf("nothing removed")
+	}
+
+	entries := make([]trashEntry, 0, len(items))
+	for _, item := range items {
+		entry, err := newTrashEntry(kind, item)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+	}
+
+	// The trash is saved before anything is deleted, so every deleted item
+	// can be restored. Items that fail to delete are taken out again.
+	trash, err := loadTrash()
+	if err != nil {
+		return err
+	}
+	err = saveTrash(slices.Concat(trash, entries))
+	if err != nil {
+		return fmt.Errorf("nothing removed, could not save the trash: %w", err)
+	}
+
+	removed := 0
+	var failures []error
+	for _, entry := range entries {
+		err = remove(entry.ID)
+		if err != nil {
+			failures = append(failures, fmt.Errorf("%s: %w", entry.ID, err))
+			continue
+		}
+		trash = append(trash, entry)
+		removed++
+	}
+	if len(failures) > 0 {
+		err = saveTrash(trash)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Could not take the items that were not removed out of the trash: %v\n", err)
+		}
+	}
+
+	if removed > 0 {
+		fmt.Printf("Removed %s. Use 'trash list' and 'trash restore <id>' to undo\n", countItems(removed))
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("could not remove %s:\n%w", countItems(len(failures)), errors.Join(failures...))
+	}
+	return nil
+}
+
+// uniqueIDs drops repeated IDs, keeping the first of each, so an item named
+// twice is only trashed and deleted once.
+func uniqueIDs(ids []string) []string {
+	seen := map[uuid.UUID]bool{}
+	var unique []string
+	for _, id := range ids {
+		parsed, err := uuid.Parse(id)
+		if err == nil {
+			if seen[parsed] {
+				continue
+			}
+			seen[parsed] = true
+		}
+		unique = append(unique, id)
+	}
+	return unique
+}
+
+// selectItems lists the items on the shelf given with --shelf, or the
+// current shelf, and returns the ones the user picks.
+func selectItems[T any](session *digitalshelfapi.Session, args commandArgs, list func(...string) ([]T, error)) ([]T, error) {
+	shelfID, err := chooseShelf(session, args)
+	if err != nil {
+		return nil, err
+	}
+	items, err := list(shelfID.String())
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+
+	labels := make([]string, 0, len(items))
+	for _, item := range items {
+		labels = append(labels, itemLabel(item))
+	}
+	chosen, err := pickMany(labels)
+	if err != nil {
+		return nil, err
+	}
+	selected := make([]T, 0, len(chosen))
+	for _, i := range chosen {
+		selected = append(selected, items[i])
+	}
+	return selected, nil
+}
+
+// itemLabel describes an item by its title and ID.
+func itemLabel(item any) string {
+	id, title := itemIdentity(item)
+	return fmt.Sprintf("%s  %s", title, id)
+}
+
+// itemIdentity returns the ID and title of an item of any type.
+func itemIdentity(item any) (uuid.UUID, string) {
+	var id uuid.UUID
+	var title string
+	for _, f := range recordFields(item) {
+		switch f.name {
+		case "id":
+			id, _ = f.value.(uuid.UUID)
+		case "title":
+			title, _ = f.value.(string)
+		}
+	}
+	return id, title
+}
+
+func countItems(n int) string {
+	return plural(n, "item", "items")
 }
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+	"github.com/google/uuid"
+)
+
+func renameLocation(session *digitalshelfapi.Session, args commandArgs) error {
+	id, err := resolveLocation(session, args.arg("location"))
+	if err != nil {
+		return err
+	}
+	location, err := session.GetLocation(id.String())
+	if err != nil {
+		return err
+	}
+	err = requireOwner(session, location, "rename")
+	if err != nil {
+		return err
+	}
+	name := args.arg("new name")
+	err = session.UpdateLocation(id, map[string]any{"name": name})
+	if err != nil {
+		return err
+	}
+	if id == session.CurrentLocation {
+		session.CurrentLocationName = name
+	}
+	fmt.Printf("Location renamed to %s\n", name)
+	return nil
+}
+
+func renameCase(session *digitalshelfapi.Session, args commandArgs) error {
+	id, err := resolveCase(session, args.arg("case"))
+	if err != nil {
+		return err
+	}
+	name := args.arg("new name")
+	err = session.UpdateCase(id, map[string]any{"name": name})
+	if err != nil {
+		return err
+	}
+	if session.CurrentShelf != uuid.Nil {
+		shelf, err := session.GetShelf(session.CurrentShelf.String())
+		if err == nil && shelf.CaseID == id {
+			session.CurrentCaseName = name
+		}
+	}
+	fmt.Printf("Case renamed to %s\n", name)
+	return nil
+}
+
+func renameShelf(session *digitalshelfapi.Session, args commandArgs) error {
+	id, err := resolveShelf(session, args.arg("shelf"))
+	if err != nil {
+		return err
+	}
+	name := args.arg("new name")
+	err = session.UpdateShelf(id, map[string]any{"name": name})
+	if err != nil {
+		return err
+	}
+	if id == session.CurrentShelf {
+		session.CurrentShelfName = name
+	}
+	fmt.Printf("Shelf renamed to %s\n", name)
+	return nil
+}
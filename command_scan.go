@@ -0,0 +1,170 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/barcode"
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+	"golang.org/x/term"
+)
+
+// scanStopWords end a scanning session when typed instead of a barcode.
+var scanStopWords = map[string]bool{"done": true, "exit": true, "quit": true, "q": true}
+
+// scanResult is what happened to one scanned barcode.
+type scanResult int
+
+const (
+	scanAdded scanResult = iota
+	scanQueued
+	scanFailed
+)
+
+// scanFeedback signals the result of each scan with the terminal bell, one
+// ring for an added item and two otherwise, and with a coloured mark when
+// stdout is a terminal.
+type scanFeedback struct {
+	bell  bool
+	color bool
+}
+
+func (f scanFeedback) report(result scanResult, message string) {
+	mark, color, rings := "+", "32", 1
+	switch result {
+	case scanQueued:
+		mark, color, rings = "?", "33", 2
+	case scanFailed:
+		mark, color, rings = "x", "31", 2
+	}
+	if f.color {
+		mark = "\033[1;" + color + "m" + mark + "\033[0m"
+	}
+	if f.bell {
+		fmt.Print(strings.Repeat("\a", rings))
+	}
+	fmt.Printf("%s %s\n", mark, message)
+}
+
+func scanMovies(session *digitalshelfapi.Session, args commandArgs) error {
+	return scanItems(session, args, movieType(session))
+}
+
+func scanShows(session *digitalshelfapi.Session, args commandArgs) error {
+	return scanItems(session, args, showType(session))
+}
+
+func scanBooks(session *digitalshelfapi.Session, args commandArgs) error {
+	return scanItems(session, args, bookType(session))
+}
+
+func scanMusic(session *digitalshelfapi.Session, args commandArgs) error {
+	return scanItems(session, args, musicType(session))
+}
+
+// scanItems reads barcodes one per line, as a USB scanner types them, and
+// adds each known item to the shelf without asking. Unknown barcodes are
+// queued and can be entered by hand once scanning ends.
+func scanItems[T any](session *digitalshelfapi.Session, args commandArgs, s itemType[T]) error {
+	shelfID, err := chooseShelf(session, args)
+	if err != nil {
+		return err
+	}
+	feedback := scanFeedback{
+		bell:  !args.enabled("quiet"),
+		color: term.IsTerminal(int(os.Stdout.Fd())),
+	}
+
+	var added, queued, skipped []string
+	var failures []error
+	fmt.Printf("Scanning %s barcodes. Type 'done' or press Ctrl-D to finish.\n", s.kind)
+	for {
+		line, err := readLine("scan> ")
+		if errors.Is(err, io.EOF) || errors.Is(err, errCancelled) {
+			fmt.Println()
+			break
+		}
+		if err != nil {
+			return err
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if scanStopWords[strings.ToLower(line)] {
+			break
+		}
+		code, err := barcode.Normalize(line)
+		if err != nil {
+			failures = append(failures, err)
+			feedback.report(scanFailed, err.Error())
+			continue
+		}
+
+		item, err := s.lookup(code)
+		if errors.Is(err, digitalshelfapi.ErrNotFound) {
+			queued = append(queued, code)
+			feedback.report(scanQueued, fmt.Sprintf("%s is unknown, queued to enter later", code))
+			continue
+		}
+		if err == nil {
+			err = s.add(shelfID, item)
+		}
+		if err != nil {
+			failures = append(failures, fmt.Errorf("%s: %w", code, err))
+			feedback.report(scanFailed, fmt.Sprintf("%s: %v", code, err))
+			continue
+		}
+		_, title := itemIdentity(item)
+		added = append(added, title)
+		feedback.report(scanAdded, title)
+	}
+
+	if len(queued) > 0 && interactive {
+		ok, err := confirm(fmt.Sprintf("Enter the details of %s now?", plural(len(queued), "unknown barcode", "unknown barcodes")))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			skipped = queued
+			queued = nil
+		}
+		for i, code := range queued {
+			fmt.Printf("\nBarcode %s\n", code)
+			item, err := s.details()
+			if errors.Is(err, errCancelled) || errors.Is(err, io.EOF) {
+				skipped = append(skipped, queued[i:]...)
+				break
+			}
+			if err == nil {
+				s.barcode(&item, code)
+				err = s.add(shelfID, item)
+			}
+			if err != nil {
+				failures = append(failures, fmt.Errorf("%s: %w", code, err))
+				feedback.report(scanFailed, fmt.Sprintf("%s: %v", code, err))
+				continue
+			}
+			_, title := itemIdentity(item)
+			added = append(added, title)
+			feedback.report(scanAdded, title)
+		}
+	} else {
+		skipped = queued
+	}
+
+	fmt.Printf("\nAdded %s, skipped %d, failed %d\n", countItems(len(added)), len(skipped), len(failures))
+	if len(added) > 0 {
+		fmt.Printf("Added: %s\n", strings.Join(added, ", "))
+	}
+	if len(skipped) > 0 {
+		fmt.Printf("Skipped: %s\n", strings.Join(skipped, ", "))
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("could not add %s:\n%w", plural(len(failures), "barcode", "barcodes"), errors.Join(failures...))
+	}
+	return nil
+}
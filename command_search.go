@@ -1,42 +1,45 @@
 package main
 
 import (
-	"fmt"
-
 	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
 )
 
-func commandSearch(session *digitalshelfapi.Session, args ...string) error {
-	if len(args) == 0 {
-		return fmt.Errorf("please specify what you want to search")
+func searchUsers(session *digitalshelfapi.Session, args commandArgs) error {
+	user, err := session.SearchUsers(args.arg("email"))
+	if err != nil {
+		return err
+	}
+	return renderItem(user)
+}
+
+func searchMovies(session *digitalshelfapi.Session, args commandArgs) error {
+	movies, err := session.SearchMovies(args.arg("term"))
+	if err != nil {
+		return err
+	}
+	return renderList(movies)
+}
+
+func searchShows(session *digitalshelfapi.Session, args commandArgs) error {
+	shows, err := session.SearchShows(args.arg("term"))
+	if err != nil {
+		return err
+	}
+	return renderList(shows)
+}
+
+func searchBooks(session *digitalshelfapi.Session, args commandArgs) error {
+	books, err := session.SearchBooks(args.arg("term"))
+	if err != nil {
+		return err
 	}
-	switch args[0] {
-	case "users":
-		if len(args) < 2 {
-			return fmt.Errorf("please specify an email address")
-		}
-		return session.SearchUsers(args[1])
-	case "movies":
-		if len(args) < 2 {
-			return fmt.Errorf("please specify a search term")
-		}
-		return session.SearchMovies(args[1])
-	case "shows":
-		if len(args) < 2 {
-			return fmt.Errorf("please specify a search term")
-		}
-		return session.SearchShows(args[1])
-	case "books":
-		if len(args) < 2 {
-			return fmt.Errorf("please specify a search term")
-		}
-		return session.SearchBooks(args[1])
-	case "music":
-		if len(args) < 2 {
-			return fmt.Errorf("please specify a search term")
-		}
-		return session.SearchMusic(args[1])
-	default:
-		return fmt.Errorf("unknown search command: %s", args[0])
+	return renderList(books)
+}
+
+func searchMusic(session *digitalshelfapi.Session, args commandArgs) error {
+	musicList, err := session.SearchMusic(args.arg("term"))
+	if err != nil {
+		return err
 	}
+	return renderList(musicList)
 }
@@ -2,20 +2,88 @@ package main
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
 )
 
-func commandSet(session *digitalshelfapi.Session, args ...string) error {
-	if len(args) == 0 {
-		return fmt.Errorf("please specify what you want to set")
+func setLocation(session *digitalshelfapi.Session, args commandArgs) error {
+	locationID, err := resolveLocation(session, args.arg("location"))
+	if err != nil {
+		return err
 	}
-	switch args[0] {
-	case "location":
-		return session.SetCurrentLocation(args[1])
-	case "shelf":
-		return session.SetCurrentShelf(args[1])
-	default:
-		return fmt.Errorf("unknown set command: %s", args[0])
+	location, err := session.SetCurrentLocation(locationID.String())
+	if err != nil {
+		return err
 	}
+	fmt.Printf("Location set to: %s\n", location.Name)
+	return nil
+}
+
+func setShelf(session *digitalshelfapi.Session, args commandArgs) error {
+	shelfID, err := resolveShelf(session, args.arg("shelf"))
+	if err != nil {
+		return err
+	}
+	shelf, err := session.SetCurrentShelf(shelfID.String())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Current shelf set to %s\n", shelf.Name)
+	return nil
+}
+
+func setOutput(session *digitalshelfapi.Session, args commandArgs) error {
+	format, err := parseOutputFormat(args.arg("format"))
+	if err != nil {
+		return err
+	}
+	currentOutput = format
+	fmt.Printf("Output format set to %s\n", format)
+	return nil
+}
+
+// setPrompt changes the REPL prompt and saves it in the config. The template
+// may use {{.User}}, {{.Email}}, {{.Profile}}, {{.Platform}}, {{.Location}},
+// {{.Case}} and {{.Shelf}}.
+func setPrompt(session *digitalshelfapi.Session, args commandArgs) error {
+	text := strings.Join(args.list("template"), " ")
+	err := setPromptTemplate(text)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	cfg.Prompt = text
+	if text == "default" {
+		cfg.Prompt = ""
+	}
+	err = saveConfig(cfg)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Prompt updated")
+	return nil
+}
+
+// setRole changes a member's role. Ownership is changed with 'transfer'
+// instead, as a location has exactly one owner.
+func setRole(session *digitalshelfapi.Session, args commandArgs) error {
+	member, err := resolveMember(session, args.arg("member"))
+	if err != nil {
+		return err
+	}
+	if member.Role == digitalshelfapi.RoleOwner {
+		return fmt.Errorf("%s owns this location; use 'transfer location' to change the owner", member.Name)
+	}
+	role := strings.ToLower(args.arg("role"))
+	err = session.SetMemberRole(member.UserID, role)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s's role in %s is now %s\n", member.Name, session.CurrentLocationName, role)
+	return nil
 }
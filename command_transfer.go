@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+)
+
+// transferLocation makes another member the owner of a location, and
+// optionally takes the previous owner out of it.
+func transferLocation(session *digitalshelfapi.Session, args commandArgs) error {
+	id, err := resolveLocation(session, args.arg("location"))
+	if err != nil {
+		return err
+	}
+	location, err := session.GetLocation(id.String())
+	if err != nil {
+		return err
+	}
+	err = requireOwner(session, location, "transfer")
+	if err != nil {
+		return err
+	}
+	member, err := resolveMemberOf(session, location.ID, args.arg("member"))
+	if err != nil {
+		return err
+	}
+	if member.UserID == session.User.ID {
+		return usageErrorf("you already own %s", location.Name)
+	}
+
+	ok, err := confirm(fmt.Sprintf("Make %s <%s> the owner of %s? Only they will be able to undo this.", member.Name, member.Email, location.Name))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("ownership not transferred")
+	}
+	err = session.TransferLocation(location.ID, member.UserID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s now owns %s\n", member.Name, location.Name)
+
+	leave := args.enabled("leave")
+	if !leave && interactive && !assumeYes {
+		leave, err = confirm(fmt.Sprintf("Leave %s as well?", location.Name))
+		if err != nil {
+			return err
+		}
+	}
+	if !leave {
+		return nil
+	}
+	err = session.LeaveLocation(location.ID)
+	if err != nil {
+		return fmt.Errorf("ownership was transferred, but you could not leave %s: %w", location.Name, err)
+	}
+	fmt.Printf("You have left %s\n", location.Name)
+	return nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+	"github.com/google/uuid"
+)
+
+func trashList(session *digitalshelfapi.Session, args commandArgs) error {
+	entries, err := loadTrash()
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 && currentOutput == formatTable {
+		fmt.Println("The trash is empty")
+		return nil
+	}
+	return renderList(entries)
+}
+
+// trashRestore adds trashed items back to the shelves they were removed
+// from. The server gives each restored item a new ID.
+func trashRestore(session *digitalshelfapi.Session, args commandArgs) error {
+	entries, err := loadTrash()
+	if err != nil {
+		return err
+	}
+
+	restore := map[uuid.UUID]bool{}
+	for _, id := range args.list("ids") {
+		restore[uuid.MustParse(id)] = true
+	}
+
+	var kept []trashEntry
+	var failures []error
+	restored := 0
+	for _, entry := range entries {
+		if !restore[entry.ID] {
+			kept = append(kept, entry)
+			continue
+		}
+		delete(restore, entry.ID)
+		restorer, ok := restorers[entry.Type]
+		if !ok {
+			failures = append(failures, fmt.Errorf("%s: cannot restore items of type %q", entry.ID, entry.Type))
+			kept = append(kept, entry)
+			continue
+		}
+		err := restorer(session, entry)
+		if err != nil {
+			failures = append(failures, fmt.Errorf("%s: %w", entry.ID, err))
+			kept = append(kept, entry)
+			continue
+		}
+		fmt.Printf("Restored %s %q\n", entry.Type, entry.Title)
+		restored++
+	}
+	for id := range restore {
+		failures = append(failures, fmt.Errorf("%s: not in the trash", id))
+	}
+
+	if restored > 0 {
+		err = saveTrash(kept)
+		if err != nil {
+			return err
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("could not restore %s:\n%w", countItems(len(failures)), errors.Join(failures...))
+	}
+	return nil
+}
+
+func trashEmpty(session *digitalshelfapi.Session, args commandArgs) error {
+	entries, err := loadTrash()
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Println("The trash is already empty")
+		return nil
+	}
+	ok, err := confirm(fmt.Sprintf("Permanently forget %s in the trash?", countItems(len(entries))))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("trash not emptied")
+	}
+	err = saveTrash(nil)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Trash emptied")
+	return nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+	"github.com/google/uuid"
+)
+
+// captureStdout returns what run prints to stdout.
+func captureStdout(t *testing.T, run func() error) (string, error) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := os.Stdout
+	os.Stdout = w
+	err = run()
+	os.Stdout = saved
+	w.Close()
+	out, _ := io.ReadAll(r)
+	return string(out), err
+}
+
+func TestTrashListWhenEmpty(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	defer func(saved outputFormat) { currentOutput = saved }(currentOutput)
+
+	cases := map[outputFormat]string{
+		formatTable: "The trash is empty\n",
+		formatJSON:  "[]\n",
+		formatJSONL: "",
+	}
+	for format, expected := range cases {
+		currentOutput = format
+		out, err := captureStdout(t, func() error { return trashList(nil, commandArgs{}) })
+		if err != nil || out != expected {
+			t.Errorf("%s: trashList printed %q (%v), expected %q", format, out, err, expected)
+		}
+	}
+}
+
+func TestTrashRestoreUnknownType(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	entry := trashEntry{
+		ID:        uuid.MustParse("55555555-5555-5555-5555-555555555551"),
+		Type:      "vinyl",
+		Title:     "Abbey Road",
+		RemovedAt: time.Now(),
+		Item:      json.RawMessage(`{}`),
+	}
+	err := saveTrash([]trashEntry{entry})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := commandArgs{lists: map[string][]string{"ids": {entry.ID.String()}}}
+	err = trashRestore(&digitalshelfapi.Session{}, args)
+	if err == nil || !strings.Contains(err.Error(), `cannot restore items of type "vinyl"`) {
+		t.Errorf("trashRestore() == %v, expected an unknown type error", err)
+	}
+	entries, err := loadTrash()
+	if err != nil || len(entries) != 1 {
+		t.Errorf("trash == %v (%v), expected the entry to be kept", entries, err)
+	}
+}
+
+func TestUniqueIDs(t *testing.T) {
+	id := "55555555-5555-5555-5555-555555555551"
+	other := "55555555-5555-5555-5555-555555555552"
+	ids := uniqueIDs([]string{id, other, strings.ToUpper(id), id})
+	if strings.Join(ids, ",") != id+","+other {
+		t.Errorf("uniqueIDs == %v, expected %v", ids, []string{id, other})
+	}
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+	"github.com/google/uuid"
+)
+
+// treeConcurrency bounds how many branches of a tree are fetched at once.
+const treeConcurrency = 8
+
+// itemKinds lists the item types in the order counts are shown, with their
+// plurals.
+var itemKinds = []struct{ kind, plural string }{
+	{"movie", "movies"},
+	{"show", "shows"},
+	{"book", "books"},
+	{"music", "music"},
+}
+
+// treeNode is a location, case, shelf or item in 'get tree'.
+type treeNode struct {
+	kind     string
+	id       uuid.UUID
+	name     string
+	counts   map[string]int
+	children []*treeNode
+}
+
+// treeRow is a tree node flattened for formats other than table.
+type treeRow struct {
+	Type     string    `json:"type"`
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	ParentID uuid.UUID `json:"parent_id"`
+	Depth    int       `json:"depth"`
+	Movies   int       `json:"movies"`
+	Shows    int       `json:"shows"`
+	Books    int       `json:"books"`
+	Music    int       `json:"music"`
+}
+
+func getTree(session *digitalshelfapi.Session, args commandArgs) error {
+	if session.CurrentLocation == uuid.Nil {
+		return fmt.Errorf("please set a location first")
+	}
+	depth := args.number("depth")
+
+	name := session.CurrentLocationName
+	if name == "" {
+		location, err := session.GetLocation(session.CurrentLocation.String())
+		if err != nil {
+			return err
+		}
+		name = location.Name
+	}
+
+	root := &treeNode{kind: "location", id: session.CurrentLocation, name: name}
+	err := fetchTree(session, root, args.enabled("items"))
+	if err != nil {
+		return err
+	}
+
+	if currentOutput == formatTable {
+		writeTree(os.Stdout, root, depth)
+		return nil
+	}
+	return renderList(treeRows(root, uuid.Nil, 0, depth))
+}
+
+// fetchTree fills in the cases of a location, their shelves and the items
+// on each shelf, fetching each branch concurrently.
+func fetchTree(session *digitalshelfapi.Session, root *treeNode, withItems bool) error {
+	cases, err := session.GetCases()
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var failures []error
+	slots := make(chan struct{}, treeConcurrency)
+	fetch := func(f func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			err := f()
+			<-slots
+			if err != nil {
+				mu.Lock()
+				failures = append(failures, err)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, c := range cases {
+		caseNode := &treeNode{kind: "case", id: c.ID, name: c.Name}
+		root.children = append(root.children, caseNode)
+		fetch(func() error {
+			shelves, err := session.GetShelves(c.ID.String())
+			if err != nil {
+				return err
+			}
+			for _, shelf := range shelves {
+				shelfNode := &treeNode{kind: "shelf", id: shelf.ID, name: shelf.Name}
+				caseNode.children = append(caseNode.children, shelfNode)
+			}
+			for _, shelfNode := range caseNode.children {
+				fetch(func() error {
+					items, _, err := shelfContents(session, shelfNode.id)
+					if err != nil {
+						return err
+					}
+					shelfNode.counts = map[string]int{}
+					for _, item := range items {
+						shelfNode.counts[item.kind]++
+						if withItems {
+							shelfNode.children = append(shelfNode.children, &treeNode{kind: item.kind, id: item.id, name: item.title})
+						}
+					}
+					return nil
+				})
+			}
+			return nil
+		})
+	}
+	wg.Wait()
+	if len(failures) > 0 {
+		return errors.Join(failures...)
+	}
+
+	sumCounts(root)
+	return nil
+}
+
+// sumCounts totals the item counts of a node's children into its own.
+func sumCounts(node *treeNode) map[string]int {
+	if node.counts != nil || isItemKind(node.kind) {
+		return node.counts
+	}
+	node.counts = map[string]int{}
+	for _, child := range node.children {
+		for kind, n := range sumCounts(child) {
+			node.counts[kind] += n
+		}
+	}
+	return node.counts
+}
+
+// writeTree draws a tree down to depth levels below the root, where cases
+// are level 1, shelves level 2 and items level 3.
+func writeTree(w io.Writer, root *treeNode, depth int) {
+	fmt.Fprintf(w, "%s  %s\n", root.name, describeCounts(root.counts))
+	writeBranches(w, root, "", depth)
+}
+
+func writeBranches(w io.Writer, node *treeNode, indent string, depth int) {
+	if depth <= 0 {
+		return
+	}
+	for i, child := range node.children {
+		branch, next := "├── ", "│   "
+		if i == len(node.children)-1 {
+			branch, next = "└── ", "    "
+		}
+		if isItemKind(child.kind) {
+			fmt.Fprintf(w, "%s%s%s: %s\n", indent, branch, child.kind, child.name)
+		} else {
+			fmt.Fprintf(w, "%s%s%s  %s\n", indent, branch, child.name, describeCounts(child.counts))
+		}
+		writeBranches(w, child, indent+next, depth-1)
+	}
+}
+
+// describeCounts lists item counts by type, such as "(2 movies, 1 book)".
+func describeCounts(counts map[string]int) string {
+	var parts []string
+	for _, k := range itemKinds {
+		if counts[k.kind] > 0 {
+			parts = append(parts, plural(counts[k.kind], k.kind, k.plural))
+		}
+	}
+	if len(parts) == 0 {
+		return "(empty)"
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+func isItemKind(kind string) bool {
+	for _, k := range itemKinds {
+		if k.kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func treeRows(node *treeNode, parentID uuid.UUID, level, depth int) []treeRow {
+	rows := []treeRow{{
+		Type:     node.kind,
+		ID:       node.id,
+		Name:     node.name,
+		ParentID: parentID,
+		Depth:    level,
+		Movies:   node.counts["movie"],
+		Shows:    node.counts["show"],
+		Books:    node.counts["book"],
+		Music:    node.counts["music"],
+	}}
+	if level < depth {
+		for _, child := range node.children {
+			rows = append(rows, treeRows(child, node.id, level+1, depth)...)
+		}
+	}
+	return rows
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteTree(t *testing.T) {
+	root := &treeNode{kind: "location", name: "Home", children: []*treeNode{
+		{kind: "case", name: "Living Room", children: []*treeNode{
+			{kind: "shelf", name: "Top", counts: map[string]int{"movie": 2, "book": 1}, children: []*treeNode{
+				{kind: "movie", name: "Alien"},
+				{kind: "movie", name: "Heat"},
+				{kind: "book", name: "Dune"},
+			}},
+			{kind: "shelf", name: "Bottom", counts: map[string]int{}},
+		}},
+		{kind: "case", name: "Garage"},
+	}}
+	sumCounts(root)
+
+	cases := []struct {
+		depth    int
+		expected string
+	}{
+		{
+			depth:    0,
+			expected: "Home  (2 movies, 1 book)\n",
+		},
+		{
+			depth: 1,
+			expected: "Home  (2 movies, 1 book)\n" +
+				"├── Living Room  (2 movies, 1 book)\n" +
+				"└── Garage  (empty)\n",
+		},
+		{
+			depth: 3,
+			expected: "Home  (2 movies, 1 book)\n" +
+				"├── Living Room  (2 movies, 1 book)\n" +
+				"│   ├── Top  (2 movies, 1 book)\n" +
+				"│   │   ├── movie: Alien\n" +
+				"│   │   ├── movie: Heat\n" +
+				"│   │   └── book: Dune\n" +
+				"│   └── Bottom  (empty)\n" +
+				"└── Garage  (empty)\n",
+		},
+	}
+
+	for _, c := range cases {
+		var buf bytes.Buffer
+		writeTree(&buf, root, c.depth)
+		if buf.String() != c.expected {
+			t.Errorf("depth %d: got\n%s\nexpected\n%s", c.depth, buf.String(), c.expected)
+		}
+	}
+}
@@ -2,24 +2,238 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
 
+	"github.com/Rodabaugh/digitalshelf-cli/internal/barcode"
 	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+	"github.com/google/uuid"
 )
 
-func commandUpdate(session *digitalshelfapi.Session, args ...string) error {
-	if len(args) == 0 {
-		return fmt.Errorf("please specify what you want to update")
+// readOnlyFields are set by the server and cannot be updated.
+var readOnlyFields = map[string]bool{
+	"id":         true,
+	"created_at": true,
+	"updated_at": true,
+}
+
+func updateMovie(session *digitalshelfapi.Session, args commandArgs) error {
+	return updateItem(session, args, "movie", session.GetMovie, session.UpdateMovie)
+}
+
+func updateShow(session *digitalshelfapi.Session, args commandArgs) error {
+	return updateItem(session, args, "show", session.GetShow, session.UpdateShow)
+}
+
+func updateBook(session *digitalshelfapi.Session, args commandArgs) error {
+	return updateItem(session, args, "book", session.GetBook, session.UpdateBook)
+}
+
+func updateMusic(session *digitalshelfapi.Session, args commandArgs) error {
+	return updateItem(session, args, "music", session.GetMusicByID, session.UpdateMusic)
+}
+
+// updateItem fetches an item, works out the changes from field=value
+// arguments or, with none, by asking for each field, then shows what will
+// change and sends only the changed fields.
+func updateItem[T any](
+	session *digitalshelfapi.Session,
+	args commandArgs,
+	kind string,
+	get func(...string) (T, error),
+	update func(uuid.UUID, map[string]any) error,
+) error {
+	id := args.id("id")
+	item, err := get(id.String())
+	if err != nil {
+		return err
 	}
-	if len(args) == 1 {
-		return fmt.Errorf("please specify what you want to update")
+	fields := editableFields(item)
+
+	assignments := args.list("changes")
+	// 'update movie <id> <shelf>' predates field updates and still moves
+	// the item.
+	if len(assignments) == 1 && !strings.Contains(assignments[0], "=") {
+		assignments = []string{"shelf_id=" + assignments[0]}
 	}
-	switch args[0] {
-	case "movie":
-		if len(args) < 3 {
-			return fmt.Errorf("please specify a movie ID and new shelf ID")
-		}
-		return session.UpdateMovieShelf(args[1], args[2])
+
+	var changes map[string]any
+	switch {
+	case len(assignments) > 0:
+		changes, err = parseChanges(session, fields, assignments)
+	case interactive:
+		changes, err = promptChanges(session, fields)
 	default:
-		return fmt.Errorf("unknown update command: %s", args[0])
+		return usageErrorf("please give the changes as field=value, for example title=\"New Title\"")
+	}
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		fmt.Println("Nothing to update")
+		return nil
+	}
+
+	writeChanges(os.Stdout, fields, changes)
+	ok, err := confirm(fmt.Sprintf("Update this %s?", kind))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%s not updated", kind)
+	}
+
+	err = update(id, changes)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s updated successfully\n", strings.ToUpper(kind[:1])+kind[1:])
+	return nil
+}
+
+// editableFields returns the fields of an item that may be updated.
+func editableFields(item any) []field {
+	var fields []field
+	for _, f := range recordFields(item) {
+		if !readOnlyFields[f.name] {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// parseChanges turns field=value arguments into the changed fields. Field
+// names are matched loosely, so "Release-Date" and "release_date" both work,
+// and "shelf" is short for shelf_id.
+func parseChanges(session *digitalshelfapi.Session, fields []field, assignments []string) (map[string]any, error) {
+	changes := map[string]any{}
+	for _, assignment := range assignments {
+		name, value, ok := strings.Cut(assignment, "=")
+		if !ok {
+			return nil, usageErrorf("expected field=value, got %q", assignment)
+		}
+		f := findField(fields, name)
+		if f == nil {
+			return nil, usageErrorf("unknown field: %s (use %s)", name, fieldNames(fields))
+		}
+		if unchanged(*f, value) {
+			continue
+		}
+		parsed, err := parseFieldValue(session, *f, value)
+		if err != nil {
+			return nil, err
+		}
+		if tableValue(parsed) != tableValue(f.value) {
+			changes[f.name] = parsed
+		}
+	}
+	return changes, nil
+}
+
+// promptChanges asks for each field in turn, starting from its current
+// value.
+func promptChanges(session *digitalshelfapi.Session, fields []field) (map[string]any, error) {
+	fmt.Println("Edit each field and press enter to keep it.")
+	changes := map[string]any{}
+	for _, f := range fields {
+		label := fieldLabel(f.name)
+		if _, isDate := f.value.(time.Time); isDate {
+			label += " (YYYY-MM-DD)"
+		}
+		value, err := readLineDefault(label+": ", tableValue(f.value))
+		if err != nil {
+			return nil, err
+		}
+		if unchanged(f, value) {
+			continue
+		}
+		parsed, err := parseFieldValue(session, f, value)
+		if err != nil {
+			return nil, err
+		}
+		if tableValue(parsed) != tableValue(f.value) {
+			changes[f.name] = parsed
+		}
+	}
+	return changes, nil
+}
+
+// unchanged reports whether value is the field's current value as it is
+// shown, so it can be kept without being parsed. An empty date is shown as
+// "", so an empty answer keeps it.
+func unchanged(f field, value string) bool {
+	return value == tableValue(f.value)
+}
+
+// parseFieldValue parses value as the type of the field's current value.
+// Shelves may be given by name and must be in the current location, and
+// barcodes are checked and normalized.
+func parseFieldValue(session *digitalshelfapi.Session, f field, value string) (any, error) {
+	switch f.name {
+	case "shelf_id":
+		shelf, err := findShelf(session, value)
+		return shelf.ID, err
+	case "barcode":
+		if value == "" {
+			return value, nil
+		}
+		code, err := barcode.Normalize(value)
+		if err != nil {
+			return nil, usageErrorf("%v", err)
+		}
+		return code, nil
+	}
+	switch f.value.(type) {
+	case time.Time:
+		t, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, usageErrorf("invalid %s: %q is not a date like 2006-01-02", f.name, value)
+		}
+		return t, nil
+	case uuid.UUID:
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, usageErrorf("invalid %s: %q is not an ID", f.name, value)
+		}
+		return id, nil
+	}
+	return value, nil
+}
+
+func findField(fields []field, name string) *field {
+	name = strings.ToLower(strings.NewReplacer("-", "_", " ", "_").Replace(name))
+	if name == "shelf" {
+		name = "shelf_id"
+	}
+	for i := range fields {
+		if fields[i].name == name {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+func fieldNames(fields []field) string {
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		names = append(names, f.name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// writeChanges shows the before and after value of each changed field.
+func writeChanges(w io.Writer, fields []field, changes map[string]any) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "FIELD\tBEFORE\tAFTER")
+	for _, f := range fields {
+		after, ok := changes[f.name]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", fieldLabel(f.name), tableValue(f.value), tableValue(after))
 	}
+	tw.Flush()
 }
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+	"github.com/google/uuid"
+)
+
+// shelfServer serves the shelves and cases findShelf looks at: the shelves
+// in home are in the location given, and the one in away is not.
+func shelfServer(t *testing.T, location uuid.UUID, home []uuid.UUID, away uuid.UUID) *digitalshelfapi.Session {
+	homeCase := uuid.MustParse("33333333-3333-3333-3333-333333333331")
+	awayCase := uuid.MustParse("33333333-3333-3333-3333-333333333332")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, id := range home {
+			if r.URL.Path == "/shelves/"+id.String() {
+				json.NewEncoder(w).Encode(digitalshelfapi.Shelf{ID: id, Name: "Home Shelf", CaseID: homeCase})
+				return
+			}
+		}
+		switch r.URL.Path {
+		case "/shelves/" + away.String():
+			json.NewEncoder(w).Encode(digitalshelfapi.Shelf{ID: away, Name: "Away Shelf", CaseID: awayCase})
+		case "/cases/" + homeCase.String():
+			json.NewEncoder(w).Encode(digitalshelfapi.Case{ID: homeCase, LocationID: location})
+		case "/cases/" + awayCase.String():
+			json.NewEncoder(w).Encode(digitalshelfapi.Case{ID: awayCase, LocationID: uuid.New()})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	session := &digitalshelfapi.Session{
+		DSAPIClient:     digitalshelfapi.NewClient(time.Second),
+		BaseURL:         server.URL + "/",
+		CurrentLocation: location,
+	}
+	session.SetTokens("token", "refresh-token")
+	return session
+}
+
+func TestParseChanges(t *testing.T) {
+	shelfID := uuid.MustParse("44444444-4444-4444-4444-444444444441")
+	newShelf := uuid.MustParse("44444444-4444-4444-4444-444444444442")
+	awayShelf := uuid.MustParse("44444444-4444-4444-4444-444444444443")
+	session := shelfServer(t, uuid.MustParse("22222222-2222-2222-2222-222222222221"), []uuid.UUID{shelfID, newShelf}, awayShelf)
+	fields := editableFields(digitalshelfapi.Movie{
+		Title:   "Star Wars",
+		Barcode: "036000291452",
+		ShelfID: shelfID,
+	})
+
+	cases := []struct {
+		assignments []string
+		expected    map[string]any
+		wantErr     bool
+		wantRefusal bool
+	}{
+		{
+			assignments: []string{`title=The Empire Strikes Back`, "Release-Date=1980-05-21"},
+			expected: map[string]any{
+				"title":        "The Empire Strikes Back",
+				"release_date": time.Date(1980, 5, 21, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			assignments: []string{"title=Star Wars", "release_date=", "barcode=036000291452"},
+			expected:    map[string]any{},
+		},
+		{
+			assignments: []string{"barcode=04252614", "shelf=" + newShelf.String()},
+			expected:    map[string]any{"barcode": "042100005264", "shelf_id": newShelf},
+		},
+		{assignments: []string{"shelf=" + awayShelf.String()}, wantRefusal: true},
+		{assignments: []string{"release_date=May 1980"}, wantErr: true},
+		{assignments: []string{"barcode=036000291453"}, wantErr: true},
+		{assignments: []string{"colour=red"}, wantErr: true},
+		{assignments: []string{"title"}, wantErr: true},
+	}
+
+	for _, c := range cases {
+		changes, err := parseChanges(session, fields, c.assignments)
+		if c.wantRefusal {
+			if err == nil {
+				t.Errorf("%v: expected the shelf to be refused", c.assignments)
+			}
+			continue
+		}
+		if c.wantErr {
+			var usageErr *usageError
+			if !errors.As(err, &usageErr) {
+				t.Errorf("%v: expected a usage error, got %v", c.assignments, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", c.assignments, err)
+			continue
+		}
+		if len(changes) != len(c.expected) {
+			t.Errorf("%v: changes == %v, expected %v", c.assignments, changes, c.expected)
+			continue
+		}
+		for name, value := range c.expected {
+			if changes[name] != value {
+				t.Errorf("%v: %s == %v, expected %v", c.assignments, name, changes[name], value)
+			}
+		}
+	}
+}
+
+func TestPromptChanges(t *testing.T) {
+	fields := editableFields(digitalshelfapi.Movie{
+		Title:   "Star Wars",
+		Genre:   "SciFi",
+		ShelfID: uuid.MustParse("44444444-4444-4444-4444-444444444441"),
+	})
+
+	// Enter keeps every field, including the empty release date, except the
+	// genre.
+	answers := make([]string, len(fields))
+	for i, f := range fields {
+		if f.name == "genre" {
+			answers[i] = "Space Opera"
+		}
+	}
+	defer func(saved *bufio.Reader) { stdin = saved }(stdin)
+	stdin = bufio.NewReader(strings.NewReader(strings.Join(answers, "\n") + "\n"))
+
+	changes, err := promptChanges(nil, fields)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(changes) != 1 || changes["genre"] != "Space Opera" {
+		t.Errorf("changes == %v, expected only genre to change", changes)
+	}
+}
@@ -0,0 +1,315 @@
+package main
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+	"github.com/google/uuid"
+)
+
+// maxRecentIDs caps how many IDs from earlier output are offered for
+// completion.
+const maxRecentIDs = 50
+
+// recentIDs holds the IDs shown by listings, most recent first.
+var recentIDs []string
+
+func rememberID(id string) {
+	recentIDs = slices.DeleteFunc(recentIDs, func(seen string) bool {
+		return seen == id
+	})
+	recentIDs = slices.Insert(recentIDs, 0, id)
+	if len(recentIDs) > maxRecentIDs {
+		recentIDs = recentIDs[:maxRecentIDs]
+	}
+}
+
+// rememberRecordIDs remembers every ID field of the rendered records.
+func rememberRecordIDs(rows [][]field) {
+	for i := len(rows) - 1; i >= 0; i-- {
+		for _, f := range rows[i] {
+			if id, ok := f.value.(uuid.UUID); ok && id != uuid.Nil {
+				rememberID(id.String())
+			}
+		}
+	}
+}
+
+// replCompleter completes command names, subcommands and arguments for the
+// line editor.
+type replCompleter struct {
+	session *digitalshelfapi.Session
+}
+
+func (c *replCompleter) Do(line []rune, pos int) ([][]rune, int) {
+	words, partial, inQuotes := splitInput(string(line[:pos]))
+	if !partial {
+		words = append(words, "")
+	}
+	prefix := words[len(words)-1]
+
+	var matches [][]rune
+	for _, candidate := range matchCandidates(completionCandidates(c.session, words[:len(words)-1], prefix), prefix) {
+		rest := candidate[len(prefix):]
+		if inQuotes {
+			rest += `"`
+		} else {
+			rest = strings.ReplaceAll(rest, " ", `\ `)
+		}
+		matches = append(matches, []rune(rest+" "))
+	}
+	return matches, len([]rune(prefix))
+}
+
+// matchCandidates keeps the candidates that start with prefix, ignoring
+// case.
+func matchCandidates(candidates []string, prefix string) []string {
+	var matches []string
+	for _, candidate := range candidates {
+		if len(candidate) >= len(prefix) && strings.EqualFold(candidate[:len(prefix)], prefix) {
+			matches = append(matches, candidate)
+		}
+	}
+	return matches
+}
+
+// completionCandidates returns what may follow the given words: command
+// names, then subcommands, then flags and argument values.
+func completionCandidates(session *digitalshelfapi.Session, previous []string, prefix string) []string {
+	commands := permittedCommands(session)
+	if len(previous) == 0 {
+		names := make([]string, 0, len(commands))
+		for name, command := range commands {
+			if !command.hidden {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	if strings.ToLower(previous[0]) == "help" {
+		if len(previous) == 1 {
+			return completionCandidates(session, nil, prefix)
+		}
+		command, ok := commands[strings.ToLower(previous[1])]
+		if !ok {
+			return nil
+		}
+		command, rest := walkSubcommands(command, previous[2:])
+		if len(rest) > 0 {
+			return nil
+		}
+		return subcommandList(command)
+	}
+
+	command, ok := commands[strings.ToLower(previous[0])]
+	if !ok {
+		return nil
+	}
+	command, rest := walkSubcommands(command, previous[1:])
+	flags := slices.Concat(command.flags, globalFlags)
+	if strings.HasPrefix(prefix, "--") {
+		names := make([]string, 0, len(flags))
+		for _, flag := range flags {
+			names = append(names, "--"+flag.name)
+		}
+		return names
+	}
+	if len(rest) > 0 && strings.HasPrefix(rest[len(rest)-1], "--") {
+		flag := findFlag(flags, strings.TrimPrefix(rest[len(rest)-1], "--"))
+		switch {
+		case flag == nil || flag.kind == kindBool:
+		case flag.complete != nil:
+			return flag.complete(session)
+		case flag.kind == kindChoice:
+			return flag.choices
+		default:
+			return nil
+		}
+	}
+
+	if len(rest) == 0 && len(command.subcommands) > 0 {
+		return subcommandList(command)
+	}
+	spec := nextArg(command, flags, rest)
+	switch {
+	case spec == nil:
+		return nil
+	case spec.complete != nil:
+		return spec.complete(session)
+	case spec.kind == kindChoice:
+		return spec.choices
+	case spec.kind == kindUUID:
+		return recentIDs
+	}
+	return nil
+}
+
+// nextArg returns the positional argument that follows words, skipping
+// flags and their values.
+func nextArg(command cliCommand, flags []flagSpec, words []string) *argSpec {
+	n := 0
+	for i := 0; i < len(words); i++ {
+		if !strings.HasPrefix(words[i], "--") {
+			n++
+			continue
+		}
+		flag := findFlag(flags, strings.TrimPrefix(words[i], "--"))
+		if flag != nil && flag.kind != kindBool {
+			i++
+		}
+	}
+	switch {
+	case n < len(command.args):
+		return &command.args[n]
+	case len(command.args) > 0 && command.args[len(command.args)-1].variadic:
+		return &command.args[len(command.args)-1]
+	}
+	return nil
+}
+
+// walkSubcommands follows the subcommands named by words, returning the last
+// one found and the words after it.
+func walkSubcommands(command cliCommand, words []string) (cliCommand, []string) {
+	for len(words) > 0 {
+		next := findSubcommand(command.subcommands, words[0])
+		if next == nil {
+			break
+		}
+		command = *next
+		words = words[1:]
+	}
+	return command, words
+}
+
+func subcommandList(command cliCommand) []string {
+	names := make([]string, 0, len(command.subcommands))
+	for _, sub := range command.subcommands {
+		names = append(names, sub.name)
+	}
+	return names
+}
+
+func findSubcommand(subcommands []cliCommand, name string) *cliCommand {
+	for i := range subcommands {
+		if subcommands[i].name == strings.ToLower(name) {
+			return &subcommands[i]
+		}
+	}
+	return nil
+}
+
+func completeLocations(session *digitalshelfapi.Session) []string {
+	locations, err := session.GetUserLocations()
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(locations))
+	for _, location := range locations {
+		names = append(names, location.LocationName)
+	}
+	return names
+}
+
+func completeInvites(session *digitalshelfapi.Session) []string {
+	invites, err := session.GetUserInvites()
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(invites))
+	for _, invite := range invites {
+		names = append(names, invite.LocationName)
+	}
+	return names
+}
+
+func completeInviteNumbers(session *digitalshelfapi.Session) []string {
+	invites, err := pendingInvites(session)
+	if err != nil {
+		return nil
+	}
+	numbers := make([]string, 0, len(invites))
+	for _, invite := range invites {
+		numbers = append(numbers, strconv.Itoa(invite.Number))
+	}
+	return numbers
+}
+
+func completeCases(session *digitalshelfapi.Session) []string {
+	cases, err := session.GetCases()
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(cases))
+	for _, c := range cases {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+func completeShelves(session *digitalshelfapi.Session) []string {
+	cases, err := session.GetCases()
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, c := range cases {
+		shelves, err := session.GetShelves(c.ID.String())
+		if err != nil {
+			return nil
+		}
+		for _, shelf := range shelves {
+			if !slices.Contains(names, shelf.Name) {
+				names = append(names, shelf.Name)
+			}
+		}
+	}
+	return names
+}
+
+func completeMembers(session *digitalshelfapi.Session) []string {
+	if session.CurrentLocation == uuid.Nil {
+		return nil
+	}
+	members, err := session.GetLocationMembers(session.CurrentLocation)
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(members))
+	for _, member := range members {
+		names = append(names, member.Name)
+	}
+	return names
+}
+
+func completeProfiles(*digitalshelfapi.Session) []string {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(cfg.Profiles)+1)
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	if _, ok := cfg.Profiles[defaultProfileName]; !ok {
+		names = append(names, defaultProfileName)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func completeTrash(*digitalshelfapi.Session) []string {
+	entries, err := loadTrash()
+	if err != nil {
+		return nil
+	}
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.ID.String())
+	}
+	return ids
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+	"github.com/google/uuid"
+)
+
+const defaultProfileName = "default"
+
+// profileNamePattern limits profile names to characters that are safe in
+// the file names of saved sessions and trash.
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+func validateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use only letters, digits, '-' and '_'", name)
+	}
+	return nil
+}
+
+// profile holds everything needed to talk to one DigitalShelf account on one
+// server. Each profile keeps its own saved session.
+type profile struct {
+	BaseURL         string    `json:"base_url"`
+	Platform        string    `json:"platform"`
+	DefaultLocation uuid.UUID `json:"default_location"`
+}
+
+type config struct {
+	CurrentProfile string             `json:"current_profile"`
+	Profiles       map[string]profile `json:"profiles"`
+	Prompt         string             `json:"prompt,omitempty"`
+}
+
+// activeProfile is the name of the profile the session was built from.
+var activeProfile = defaultProfileName
+
+func configFilePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "digitalshelf", "config.json"), nil
+}
+
+func loadConfig() (config, error) {
+	cfg := config{
+		Profiles: map[string]profile{},
+	}
+	path, err := configFilePath()
+	if err != nil {
+		return cfg, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+	err = json.Unmarshal(data, &cfg)
+	if err != nil {
+		return cfg, fmt.Errorf("error reading %s: %v", path, err)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]profile{}
+	}
+	return cfg, nil
+}
+
+func saveConfig(cfg config) error {
+	path, err := configFilePath()
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+func newProfile(baseURL, platform string) (profile, error) {
+	if baseURL == "" {
+		return profile{}, fmt.Errorf("a base URL is required")
+	}
+	if platform != "dev" && platform != "prod" {
+		return profile{}, fmt.Errorf("platform must be either dev or prod")
+	}
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	return profile{
+		BaseURL:  baseURL,
+		Platform: platform,
+	}, nil
+}
+
+// profileFromEnv builds the implicit default profile from API_BASE_URL and
+// PLATFORM, for setups that predate profiles.
+func profileFromEnv() (profile, error) {
+	baseURL := os.Getenv("API_BASE_URL")
+	if baseURL == "" {
+		return profile{}, fmt.Errorf("API_BASE_URL must be set")
+	}
+	platform := os.Getenv("PLATFORM")
+	if platform != "dev" && platform != "prod" {
+		return profile{}, fmt.Errorf("PLATFORM must be set to either dev or prod")
+	}
+	return newProfile(baseURL, platform)
+}
+
+// selectProfile picks the profile to start with: the requested one, then the
+// one last chosen with 'profile use', then the default profile.
+func selectProfile(cfg config, requested string) (string, profile, error) {
+	name := requested
+	if name == "" {
+		name = cfg.CurrentProfile
+	}
+	if name == "" {
+		name = defaultProfileName
+	}
+	err := validateProfileName(name)
+	if err != nil {
+		return "", profile{}, err
+	}
+
+	prof, ok := cfg.Profiles[name]
+	if ok {
+		return name, prof, nil
+	}
+	if name != defaultProfileName {
+		return "", profile{}, fmt.Errorf("unknown profile: %s", name)
+	}
+	prof, err = profileFromEnv()
+	if err != nil {
+		return "", profile{}, err
+	}
+	return name, prof, nil
+}
+
+func newSession(prof profile) digitalshelfapi.Session {
+	return digitalshelfapi.Session{
+		DSAPIClient: digitalshelfapi.NewClient(time.Second * 10),
+		BaseURL:     prof.BaseURL,
+		Platform:    prof.Platform,
+	}
+}
+
+// startSession replaces session with a fresh one for the named profile and
+// restores that profile's saved state.
+func startSession(session *digitalshelfapi.Session, name string, prof profile) {
+	*session = newSession(prof)
+	activeProfile = name
+	savedState = sessionState{}
+	restoreSession(session)
+
+	if session.LoggedIn() && session.CurrentLocation == uuid.Nil && prof.DefaultLocation != uuid.Nil {
+		_, err := session.SetCurrentLocation(prof.DefaultLocation.String())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Could not set the default location: %v\n", err)
+		}
+	}
+}
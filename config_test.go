@@ -0,0 +1,60 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestValidateProfileName(t *testing.T) {
+	cases := map[string]bool{
+		"default":    true,
+		"work-2":     true,
+		"home_lab":   true,
+		"":           false,
+		"../../foo":  false,
+		"a/b":        false,
+		`a\b`:        false,
+		".":          false,
+		"with space": false,
+	}
+	for name, valid := range cases {
+		err := validateProfileName(name)
+		if (err == nil) != valid {
+			t.Errorf("validateProfileName(%q) == %v, expected valid == %v", name, err, valid)
+		}
+	}
+}
+
+func TestProfileNamesStayInStateDir(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("XDG_STATE_HOME", dir)
+
+	victim := filepath.Join(dir, "victim.json")
+	err := os.WriteFile(victim, []byte("{}"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := "../../victim"
+
+	cfg := config{Profiles: map[string]profile{}}
+	if addProfile(cfg, name, "https://shelf.example.com/api/", "dev", uuid.Nil) == nil {
+		t.Errorf("addProfile(%q) succeeded", name)
+	}
+	cfg.Profiles[name] = profile{BaseURL: "https://shelf.example.com/api/", Platform: "dev"}
+	if _, _, err := selectProfile(cfg, name); err == nil {
+		t.Errorf("selectProfile(%q) succeeded", name)
+	}
+	if removeProfile(cfg, name) == nil {
+		t.Errorf("removeProfile(%q) succeeded", name)
+	}
+	if _, err := stateFilePath(name); err == nil {
+		t.Errorf("stateFilePath(%q) succeeded", name)
+	}
+	if _, err := os.Stat(victim); err != nil {
+		t.Errorf("%s was removed: %v", victim, err)
+	}
+}
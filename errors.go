@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+)
+
+// Exit codes used when running a single command or a script.
+const (
+	exitOK       = 0
+	exitError    = 1
+	exitUsage    = 2
+	exitAuth     = 3
+	exitNotFound = 4
+	exitServer   = 5
+)
+
+// usageError reports a command that was called with missing or invalid
+// arguments.
+type usageError struct {
+	message string
+}
+
+func (e *usageError) Error() string {
+	return e.message
+}
+
+func usageErrorf(format string, a ...any) error {
+	return &usageError{message: fmt.Sprintf(format, a...)}
+}
+
+func exitCode(err error) int {
+	var usageErr *usageError
+	var urlErr *url.Error
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.As(err, &usageErr):
+		return exitUsage
+	case errors.Is(err, digitalshelfapi.ErrNotLoggedIn),
+		errors.Is(err, digitalshelfapi.ErrSessionExpired),
+		errors.Is(err, digitalshelfapi.ErrUnauthorized),
+		errors.Is(err, digitalshelfapi.ErrForbidden):
+		return exitAuth
+	case errors.Is(err, digitalshelfapi.ErrNotFound):
+		return exitNotFound
+	case errors.Is(err, digitalshelfapi.ErrServer), errors.As(err, &urlErr):
+		return exitServer
+	}
+	return exitError
+}
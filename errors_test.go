@@ -0,0 +1,35 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+)
+
+func TestExitCode(t *testing.T) {
+	cases := []struct {
+		err      error
+		expected int
+	}{
+		{err: nil, expected: exitOK},
+		{err: errors.New("nothing removed"), expected: exitError},
+		{err: usageErrorf("missing shelf"), expected: exitUsage},
+		{err: fmt.Errorf("line 3: %w", usageErrorf("missing shelf")), expected: exitUsage},
+		{err: digitalshelfapi.ErrNotLoggedIn, expected: exitAuth},
+		{err: digitalshelfapi.ErrSessionExpired, expected: exitAuth},
+		{err: fmt.Errorf("error getting shelf: %w", digitalshelfapi.ErrUnauthorized), expected: exitAuth},
+		{err: digitalshelfapi.ErrForbidden, expected: exitAuth},
+		{err: fmt.Errorf("error getting shelf: %w", digitalshelfapi.ErrNotFound), expected: exitNotFound},
+		{err: digitalshelfapi.ErrServer, expected: exitServer},
+		{err: &url.Error{Op: "Get", URL: "https://shelf.example.com/api/", Err: errors.New("connection refused")}, expected: exitServer},
+		{err: errScriptPrompt, expected: exitUsage},
+	}
+	for _, c := range cases {
+		if code := exitCode(c.err); code != c.expected {
+			t.Errorf("exitCode(%v) == %d, expected %d", c.err, code, c.expected)
+		}
+	}
+}
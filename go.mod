@@ -3,6 +3,10 @@ module github.com/Rodabaugh/digitalshelf-cli
 go 1.23.4
 
 require (
+	github.com/chzyer/readline v1.5.1
 	github.com/google/uuid v1.6.0
 	github.com/joho/godotenv v1.5.1
+	golang.org/x/term v0.27.0
 )
+
+require golang.org/x/sys v0.28.0 // indirect
@@ -0,0 +1,185 @@
+package barcode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalid is matched by every error Normalize returns.
+var ErrInvalid = errors.New("invalid barcode")
+
+// Error explains why a code is not a valid barcode.
+type Error struct {
+	Code   string
+	Reason string
+}
+
+func (e *Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("invalid barcode: %s", e.Reason)
+	}
+	return fmt.Sprintf("invalid barcode %q: %s", e.Code, e.Reason)
+}
+
+func (e *Error) Is(target error) bool {
+	return target == ErrInvalid
+}
+
+func invalid(code, format string, a ...any) error {
+	return &Error{Code: code, Reason: fmt.Sprintf(format, a...)}
+}
+
+// Normalize checks a UPC-A, UPC-E, EAN-8, EAN-13, ISBN-10 or ISBN-13 code
+// and returns it in the form lookups and stored items use: UPC-E codes are
+// expanded to UPC-A, ISBN-10 codes are converted to ISBN-13, and leading
+// zeros are added or removed so the same product always has the same code.
+// Spaces and hyphens are ignored.
+func Normalize(code string) (string, error) {
+	code = strings.TrimSpace(code)
+	digits := strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(code))
+	if digits == "" {
+		return "", invalid("", "no digits were given")
+	}
+	if len(digits) == 10 && isDigits(digits[:9]) && (isDigits(digits[9:]) || digits[9] == 'X') {
+		return normalizeISBN10(code, digits)
+	}
+	if !isDigits(digits) {
+		return "", invalid(code, "barcodes are made of digits only")
+	}
+
+	// A code padded out from 8 digits is an EAN-8, as GS1 pads them to
+	// GTIN-12, 13 and 14. UPC-E codes are padded as their UPC-A form.
+	trimmed := strings.TrimLeft(digits, "0")
+	if len(digits) > 8 && len(trimmed) <= 8 {
+		return checkGTIN(code, strings.Repeat("0", 8-len(trimmed))+trimmed, "EAN-8")
+	}
+
+	// GTIN-14 and longer codes are padded EAN-13 or UPC-A codes.
+	if len(digits) > 13 {
+		if len(trimmed) > 13 {
+			return "", invalid(code, "too many digits")
+		}
+		digits = strings.Repeat("0", max(12-len(trimmed), 0)) + trimmed
+	}
+
+	switch len(digits) {
+	case 8:
+		return normalizeShort(code, digits)
+	case 11:
+		// A UPC-A code that lost its leading zero, as spreadsheets do.
+		return checkGTIN(code, "0"+digits, "UPC-A")
+	case 12:
+		return checkGTIN(code, digits, "UPC-A")
+	case 13:
+		if digits[0] == '0' {
+			return checkGTIN(code, digits[1:], "UPC-A")
+		}
+		return checkGTIN(code, digits, "EAN-13")
+	}
+	return "", invalid(code, "expected 8, 12 or 13 digits, or a 10-character ISBN")
+}
+
+// IsISBN reports whether a normalized code is an ISBN-13, which is an
+// EAN-13 in the 978 or 979 "Bookland" range. 979-0 is left to ISMNs.
+func IsISBN(code string) bool {
+	return len(code) == 13 && (strings.HasPrefix(code, "978") || strings.HasPrefix(code, "979")) && !IsISMN(code)
+}
+
+// IsISMN reports whether a normalized code is an ISMN, the 979-0 range used
+// for printed music.
+func IsISMN(code string) bool {
+	return len(code) == 13 && strings.HasPrefix(code, "9790")
+}
+
+// normalizeShort handles 8-digit codes, which are UPC-E when they start with
+// a 0 or 1 number system and their check digit matches the expanded UPC-A,
+// and EAN-8 otherwise.
+func normalizeShort(code, digits string) (string, error) {
+	if digits[0] == '0' || digits[0] == '1' {
+		upcA := expandUPCE(digits)
+		if checkDigit(upcA[:11]) == upcA[11] {
+			return upcA, nil
+		}
+		if checkDigit(digits[:7]) != digits[7] {
+			return "", invalid(code, "the check digit should be %c for a UPC-E code or %c for an EAN-8 code", checkDigit(upcA[:11]), checkDigit(digits[:7]))
+		}
+		return digits, nil
+	}
+	return checkGTIN(code, digits, "EAN-8")
+}
+
+// expandUPCE returns the UPC-A code a UPC-E code stands for. The sixth
+// digit says where the zeros that UPC-E suppresses belong.
+func expandUPCE(upcE string) string {
+	ns, d, check := upcE[:1], upcE[1:7], upcE[7:]
+	var body string
+	switch d[5] {
+	case '0', '1', '2':
+		body = d[0:2] + d[5:6] + "0000" + d[2:5]
+	case '3':
+		body = d[0:3] + "00000" + d[3:5]
+	case '4':
+		body = d[0:4] + "00000" + d[4:5]
+	default:
+		body = d[0:5] + "0000" + d[5:6]
+	}
+	return ns + body + check
+}
+
+// normalizeISBN10 checks an ISBN-10 and converts it to ISBN-13.
+func normalizeISBN10(code, digits string) (string, error) {
+	sum := 0
+	for i := range 9 {
+		sum += (10 - i) * int(digits[i]-'0')
+	}
+	want := byte('0' + (11-sum%11)%11)
+	if want == '0'+10 {
+		want = 'X'
+	}
+	if digits[9] != want {
+		return "", invalid(code, "the check digit should be %c for an ISBN-10", want)
+	}
+	isbn := "978" + digits[:9]
+	return isbn + string(checkDigit(isbn)), nil
+}
+
+// checkGTIN returns digits if its last digit is the right check digit.
+func checkGTIN(code, digits, format string) (string, error) {
+	last := len(digits) - 1
+	want := checkDigit(digits[:last])
+	if digits[last] != want {
+		return "", invalid(code, "the check digit should be %c for %s code", want, article(format))
+	}
+	return digits, nil
+}
+
+// checkDigit returns the GS1 check digit for a UPC or EAN code without its
+// check digit: digits are weighted 3 and 1 alternately from the right.
+func checkDigit(digits string) byte {
+	sum := 0
+	for i := range len(digits) {
+		weight := 1
+		if (len(digits)-i)%2 == 1 {
+			weight = 3
+		}
+		sum += weight * int(digits[i]-'0')
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+func article(format string) string {
+	if strings.HasPrefix(format, "E") {
+		return "an " + format
+	}
+	return "a " + format
+}
+
+func isDigits(s string) bool {
+	for i := range len(s) {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
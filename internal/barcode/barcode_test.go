@@ -0,0 +1,87 @@
+package barcode
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	cases := []struct {
+		code string
+		want string
+	}{
+		{code: "036000291452", want: "036000291452"},
+		{code: "36000291452", want: "036000291452"},
+		{code: "0036000291452", want: "036000291452"},
+		{code: "00036000291452", want: "036000291452"},
+		{code: "04252614", want: "042100005264"},
+		{code: "01234565", want: "012345000065"},
+		{code: "96385074", want: "96385074"},
+		{code: "00000096385074", want: "96385074"},
+		{code: "0000096385074", want: "96385074"},
+		{code: "00000001234565", want: "01234565"},
+		{code: "4006381333931", want: "4006381333931"},
+		{code: "978-0-306-40615-7", want: "9780306406157"},
+		{code: "0-306-40615-2", want: "9780306406157"},
+		{code: "080442957x", want: "9780804429573"},
+		{code: " 0316769487 ", want: "9780316769488"},
+	}
+	for _, c := range cases {
+		got, err := Normalize(c.code)
+		if err != nil {
+			t.Errorf("Normalize(%q) returned error: %v", c.code, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("Normalize(%q) = %q, want %q", c.code, got, c.want)
+		}
+	}
+}
+
+func TestNormalizeInvalid(t *testing.T) {
+	cases := []struct {
+		code    string
+		message string
+	}{
+		{code: "036000291453", message: `invalid barcode "036000291453": the check digit should be 2 for a UPC-A code`},
+		{code: "4006381333932", message: `invalid barcode "4006381333932": the check digit should be 1 for an EAN-13 code`},
+		{code: "96385075", message: `invalid barcode "96385075": the check digit should be 4 for an EAN-8 code`},
+		{code: "04252615", message: `invalid barcode "04252615": the check digit should be 4 for a UPC-E code or 0 for an EAN-8 code`},
+		{code: "0306406153", message: `invalid barcode "0306406153": the check digit should be 2 for an ISBN-10`},
+		{code: "12345", message: `invalid barcode "12345": expected 8, 12 or 13 digits, or a 10-character ISBN`},
+		{code: "12A456789012", message: `invalid barcode "12A456789012": barcodes are made of digits only`},
+		{code: "", message: "invalid barcode: no digits were given"},
+	}
+	for _, c := range cases {
+		_, err := Normalize(c.code)
+		if !errors.Is(err, ErrInvalid) {
+			t.Errorf("Normalize(%q) error = %v, want ErrInvalid", c.code, err)
+			continue
+		}
+		if err.Error() != c.message {
+			t.Errorf("Normalize(%q) error = %q, want %q", c.code, err, c.message)
+		}
+	}
+}
+
+func TestIsISBN(t *testing.T) {
+	cases := []struct {
+		code string
+		isbn bool
+		ismn bool
+	}{
+		{code: "9780306406157", isbn: true},
+		{code: "9791234567896", isbn: true},
+		{code: "9790260000438", ismn: true},
+		{code: "4006381333931"},
+		{code: "036000291452"},
+	}
+	for _, c := range cases {
+		if got := IsISBN(c.code); got != c.isbn {
+			t.Errorf("IsISBN(%q) = %v, want %v", c.code, got, c.isbn)
+		}
+		if got := IsISMN(c.code); got != c.ismn {
+			t.Errorf("IsISMN(%q) = %v, want %v", c.code, got, c.ismn)
+		}
+	}
+}
@@ -10,28 +10,45 @@ import (
 	"github.com/google/uuid"
 )
 
+// loginResponse is what the server returns for a successful login.
+type loginResponse struct {
+	ID           uuid.UUID `json:"id"`
+	Name         string    `json:"name"`
+	Email        string    `json:"email"`
+	Token        string    `json:"token"`
+	RefreshToken string    `json:"refresh_token"`
+}
+
 func (session *Session) Authenticate(args ...string) error {
-	type response struct {
-		ID           uuid.UUID `json:"id"`
-		Name         string    `json:"name"`
-		Email        string    `json:"email"`
-		Token        string    `json:"token"`
-		RefreshToken string    `json:"refresh_token"`
+	if len(args) < 2 {
+		return errors.New("email and password are required")
+	}
+
+	response, err := session.login(args[0], args[1])
+	if err != nil {
+		return err
 	}
+	session.User.ID = response.ID
+	session.User.Email = response.Email
+	session.User.Name = response.Name
+	session.SetTokens(response.Token, response.RefreshToken)
+	return nil
+}
 
+// login checks an email and password with the server and returns the new
+// server session without storing it.
+func (session *Session) login(email, password string) (loginResponse, error) {
 	type parameters struct {
 		Email    string
 		Password string
 	}
 
 	url := session.BaseURL + "login"
-	email := args[0]
 	if email == "" {
-		return errors.New("email is required")
+		return loginResponse{}, errors.New("email is required")
 	}
-	password := args[1]
 	if password == "" {
-		return errors.New("password is required")
+		return loginResponse{}, errors.New("password is required")
 	}
 
 	params := parameters{
@@ -41,43 +58,126 @@ func (session *Session) Authenticate(args ...string) error {
 
 	request_data, err := json.Marshal(params)
 	if err != nil {
-		return err
+		return loginResponse{}, err
 	}
 
 	req, err := http.NewRequest("POST", url, bytes.NewBuffer(request_data))
 	if err != nil {
-		return err
+		return loginResponse{}, err
 	}
 
 	res, err := session.DSAPIClient.HttpClient.Do(req)
 	if err != nil {
-		fmt.Println("error making request: ", err)
+		return loginResponse{}, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 200 {
-		var response response
+	if res.StatusCode == http.StatusOK {
+		var response loginResponse
 		err = json.NewDecoder(res.Body).Decode(&response)
 		if err != nil {
-			return err
+			return loginResponse{}, err
 		}
-		session.User.ID = response.ID
-		session.User.Email = response.Email
-		session.User.Name = response.Name
-		session.Token = response.Token
-		session.RefreshToken = response.RefreshToken
-		return nil
+		return response, nil
+	}
+	if res.StatusCode == http.StatusUnauthorized {
+		return loginResponse{}, fmt.Errorf("invalid email or password: %w", newAPIError(res))
+	}
+
+	return loginResponse{}, fmt.Errorf("error authenticating: %w", newAPIError(res))
+}
+
+// revoke ends the server session the given access token belongs to.
+func (session *Session) revoke(token string) error {
+	url := session.BaseURL + "revoke"
+	req, err := http.NewRequest("POST", url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	res, err := session.DSAPIClient.HttpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("error logging out: %w", newAPIError(res))
+	}
+	return nil
+}
+
+// RefreshAccessToken exchanges the stored refresh token for a new access
+// token. If the server refuses, the session is cleared and ErrSessionExpired
+// is returned.
+func (session *Session) RefreshAccessToken() error {
+	session.tokenMu.Lock()
+	defer session.tokenMu.Unlock()
+	return session.refreshAccessToken()
+}
+
+// refreshAccessToken must be called with tokenMu held.
+func (session *Session) refreshAccessToken() error {
+	if session.RefreshToken == "" {
+		return ErrNotLoggedIn
+	}
+
+	type response struct {
+		Token string `json:"token"`
+	}
+
+	url := session.BaseURL + "refresh"
+	req, err := http.NewRequest("POST", url, nil)
+	if err != nil {
+		return err
 	}
-	if res.StatusCode == 401 {
-		return errors.New("invalid email or password")
+	req.Header.Set("Authorization", "Bearer "+session.RefreshToken)
+
+	res, err := session.DSAPIClient.HttpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
 	}
+	defer res.Body.Close()
 
-	return errors.New("error authenticating")
+	if res.StatusCode == http.StatusUnauthorized {
+		session.clear()
+		return ErrSessionExpired
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("error refreshing session: %w", newAPIError(res))
+	}
+
+	var refreshed response
+	err = json.NewDecoder(res.Body).Decode(&refreshed)
+	if err != nil {
+		return err
+	}
+	if refreshed.Token == "" {
+		session.clear()
+		return ErrSessionExpired
+	}
+	session.Token = refreshed.Token
+	return nil
+}
+
+// clear forgets the logged in user and everything tied to them. It must be
+// called with tokenMu held.
+func (session *Session) clear() {
+	session.User = User{}
+	session.Token = ""
+	session.RefreshToken = ""
+	session.CurrentLocation = uuid.Nil
+	session.CurrentShelf = uuid.Nil
+	session.CurrentLocationName = ""
+	session.CurrentCaseName = ""
+	session.CurrentShelfName = ""
+	session.CurrentRole = ""
 }
 
 func validateLoggedIn(session *Session) error {
-	if session.Token == "" {
-		return errors.New("you must be logged in to do that")
+	if !session.LoggedIn() {
+		return ErrNotLoggedIn
 	}
 	return nil
 }
@@ -93,21 +193,19 @@ func (session *Session) Logout() error {
 	if err != nil {
 		return err
 	}
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		fmt.Println("error making request: ", err)
+		return fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode == http.StatusNoContent {
-		fmt.Println("Logged out successfully")
-		session.Token = ""
-		session.RefreshToken = ""
+		session.tokenMu.Lock()
+		session.clear()
+		session.tokenMu.Unlock()
 		return nil
 	} else {
-		return fmt.Errorf("error logging out")
+		return fmt.Errorf("error logging out: %w", newAPIError(res))
 	}
 }
 
@@ -122,19 +220,19 @@ func (session *Session) RevokeAllSessions() error {
 	if err != nil {
 		return err
 	}
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		fmt.Println("error making request: ", err)
+		return fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode == http.StatusOK {
-		fmt.Printf("All sessions revoked\nYou are now logged out\n")
+		session.tokenMu.Lock()
+		session.clear()
+		session.tokenMu.Unlock()
 		return nil
 	} else {
-		return fmt.Errorf("error revoking sessions")
+		return fmt.Errorf("error revoking sessions: %w", newAPIError(res))
 	}
 }
 
@@ -144,11 +242,30 @@ func (session *Session) ChangePassword(args ...string) error {
 		return err
 	}
 
-	newPassword := args[0]
+	if len(args) < 2 {
+		return errors.New("current and new password are required")
+	}
+
+	currentPassword := args[0]
+	newPassword := args[1]
 	if newPassword == "" {
 		return errors.New("new password is required")
 	}
 
+	// Logging in with the current password proves it without touching this
+	// session's tokens. The extra server session is ended straight away.
+	check, err := session.login(session.User.Email, currentPassword)
+	if errors.Is(err, ErrUnauthorized) {
+		return errors.New("current password is incorrect")
+	}
+	if err != nil {
+		return err
+	}
+	err = session.revoke(check.Token)
+	if err != nil {
+		return err
+	}
+
 	type parameters struct {
 		Email    string `json:"email"`
 		Password string `json:"password"`
@@ -169,18 +286,14 @@ func (session *Session) ChangePassword(args ...string) error {
 	if err != nil {
 		return err
 	}
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		fmt.Println("error making request: ", err)
+		return fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode == http.StatusOK {
-		fmt.Println("Password changed successfully")
 		return nil
-	} else {
-		return fmt.Errorf("error changing password")
 	}
+	return fmt.Errorf("error changing password: %w", newAPIError(res))
 }
@@ -0,0 +1,86 @@
+package digitalshelfapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestChangePasswordKeepsSession(t *testing.T) {
+	revoked := map[string]int{}
+	changes := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			var params struct{ Email, Password string }
+			json.NewDecoder(r.Body).Decode(&params)
+			if params.Email != "luke@example.com" || params.Password != "old-password1" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"token": "check-token", "refresh_token": "check-refresh"})
+		case "/revoke":
+			revoked[r.Header.Get("Authorization")]++
+			w.WriteHeader(http.StatusNoContent)
+		case "/users":
+			var params struct{ Password string }
+			json.NewDecoder(r.Body).Decode(&params)
+			if r.Header.Get("Authorization") != "Bearer token" || params.Password == "forbidden1" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			changes++
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	session := &Session{
+		DSAPIClient: NewClient(time.Second),
+		BaseURL:     server.URL + "/",
+		User:        User{Email: "luke@example.com"},
+	}
+	session.SetTokens("token", "refresh-token")
+
+	cases := []struct {
+		current   string
+		password  string
+		message   string
+		forbidden bool
+		changes   int
+	}{
+		{current: "wrong-password1", password: "new-password1", message: "current password is incorrect"},
+		{current: "old-password1", password: "forbidden1", forbidden: true},
+		{current: "old-password1", password: "new-password1", changes: 1},
+	}
+	for _, c := range cases {
+		err := session.ChangePassword(c.current, c.password)
+		switch {
+		case c.message != "":
+			if err == nil || err.Error() != c.message {
+				t.Errorf("ChangePassword(%q) == %v, expected %q", c.current, err, c.message)
+			}
+		case c.forbidden:
+			if !errors.Is(err, ErrForbidden) || err.Error() == "current password is incorrect" {
+				t.Errorf("ChangePassword(%q, %q) == %v, expected the server's refusal", c.current, c.password, err)
+			}
+		case err != nil:
+			t.Errorf("ChangePassword(%q) == %v, expected no error", c.current, err)
+		}
+		if changes != c.changes {
+			t.Errorf("ChangePassword(%q, %q): %d changes, expected %d", c.current, c.password, changes, c.changes)
+		}
+		token, refreshToken := session.Tokens()
+		if token != "token" || refreshToken != "refresh-token" {
+			t.Errorf("ChangePassword(%q) changed the session: token=%q refresh token=%q", c.current, token, refreshToken)
+		}
+	}
+	if revoked["Bearer check-token"] != 2 || revoked["Bearer token"] != 0 {
+		t.Errorf("revoked == %v, expected only the two checking sessions", revoked)
+	}
+}
@@ -29,31 +29,20 @@ func (session *Session) LookupBookBarcode(args ...string) (Book, error) {
 		return Book{}, fmt.Errorf("error creating request: %v", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return Book{}, fmt.Errorf("error making request: %v", err)
+		return Book{}, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == http.StatusNotFound {
-		return Book{}, fmt.Errorf("book not found")
-	}
-
 	if res.StatusCode != http.StatusOK {
-		return Book{}, fmt.Errorf("error looking up book: %v", res.Status)
+		return Book{}, fmt.Errorf("error looking up book: %w", newAPIError(res))
 	}
 
 	var book Book
 	if err := json.NewDecoder(res.Body).Decode(&book); err != nil {
 		return Book{}, fmt.Errorf("error decoding response: %v", err)
 	}
-	fmt.Println("Book found")
-	fmt.Printf("Title: %s\n", book.Title)
-	fmt.Printf("Author: %s\n", book.Author)
-	fmt.Printf("Genre: %s\n", book.Genre)
-	fmt.Printf("Publication Date: %s\n", book.PublicationDate)
 
 	return book, nil
 }
@@ -95,30 +84,27 @@ func (session *Session) AddBook(shelfID uuid.UUID, book Book) error {
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode == http.StatusCreated {
-		fmt.Println("Book added successfully")
 		return nil
 	}
 
-	return fmt.Errorf("error adding book: %v", res.Status)
+	return fmt.Errorf("error adding book: %w", newAPIError(res))
 }
 
-func (session *Session) GetBooks(args ...string) error {
+func (session *Session) GetBooks(args ...string) ([]Book, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(args) < 1 {
-		return fmt.Errorf("please specify a shelf ID")
+		return nil, fmt.Errorf("please specify a shelf ID")
 	}
 
 	shelfID := args[0]
@@ -127,146 +113,119 @@ func (session *Session) GetBooks(args ...string) error {
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
+		return nil, fmt.Errorf("error creating request: %v", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return nil, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("error getting books: %v", res.Status)
+		return nil, fmt.Errorf("error getting books: %w", newAPIError(res))
 	}
 
 	var books []Book
 	if err := json.NewDecoder(res.Body).Decode(&books); err != nil {
-		return fmt.Errorf("error decoding response: %v", err)
+		return nil, fmt.Errorf("error decoding response: %v", err)
 	}
 
-	for _, book := range books {
-		fmt.Printf("Title: %s\n", book.Title)
-		fmt.Printf("Author: %s\n", book.Author)
-		fmt.Printf("Genre: %s\n", book.Genre)
-		fmt.Printf("Publication Date: %s\n", book.PublicationDate)
-		fmt.Println()
-	}
-
-	return nil
+	return books, nil
 }
 
-func (session *Session) GetAllLocationBooks(args ...string) error {
+func (session *Session) GetAllLocationBooks(args ...string) ([]Book, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return fmt.Errorf("you must be logged in to do that")
+		return nil, err
 	}
 
 	if len(args) < 1 {
-		return fmt.Errorf("please specify a location ID")
+		return nil, fmt.Errorf("please specify a location ID")
 	}
 
 	locationID, err := uuid.Parse(args[0])
 	if err != nil {
-		return fmt.Errorf("invalid location ID: %v", err)
+		return nil, fmt.Errorf("invalid location ID: %v", err)
 	}
 
 	url := session.BaseURL + "locations/" + locationID.String() + "/books"
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
+		return nil, fmt.Errorf("error creating request: %v", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return nil, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("error getting books: %v", res.Status)
+		return nil, fmt.Errorf("error getting books: %w", newAPIError(res))
 	}
 
 	var books []Book
 	if err := json.NewDecoder(res.Body).Decode(&books); err != nil {
-		return fmt.Errorf("error decoding response: %v", err)
+		return nil, fmt.Errorf("error decoding response: %v", err)
 	}
 
-	for _, book := range books {
-		fmt.Printf("ID: %s\n", book.ID)
-		fmt.Printf("Title: %s\n", book.Title)
-		fmt.Printf("Author: %s\n", book.Author)
-		fmt.Printf("Publication Date: %s\n", book.PublicationDate)
-		fmt.Println()
-	}
-	return nil
+	return books, nil
 }
 
-func (session *Session) GetBook(args ...string) error {
+func (session *Session) GetBook(args ...string) (Book, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return err
+		return Book{}, err
 	}
 
 	if len(args) < 1 {
-		return fmt.Errorf("please specify a book ID")
+		return Book{}, fmt.Errorf("please specify a book ID")
 	}
 
 	bookUUID, err := uuid.Parse(args[0])
 	if err != nil {
-		return fmt.Errorf("invalid book ID: %v", err)
+		return Book{}, fmt.Errorf("invalid book ID: %v", err)
 	}
 
 	url := session.BaseURL + "books/" + bookUUID.String()
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
+		return Book{}, fmt.Errorf("error creating request: %v", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return Book{}, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("error getting book: %v", res.Status)
+		return Book{}, fmt.Errorf("error getting book: %w", newAPIError(res))
 	}
 
 	var book Book
 	if err := json.NewDecoder(res.Body).Decode(&book); err != nil {
-		return fmt.Errorf("error decoding response: %v", err)
+		return Book{}, fmt.Errorf("error decoding response: %v", err)
 	}
 
-	fmt.Printf("Title: %s\n", book.Title)
-	fmt.Printf("Author: %s\n", book.Author)
-	fmt.Printf("Genre: %s\n", book.Genre)
-	fmt.Printf("Publication Date: %s\n", book.PublicationDate)
-	fmt.Printf("Barcode: %s\n", book.Barcode)
-
-	return nil
+	return book, nil
 }
 
-func (session *Session) SearchBooks(args ...string) error {
+func (session *Session) SearchBooks(args ...string) ([]Book, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(args) < 1 {
-		return fmt.Errorf("please provide a search query")
+		return nil, fmt.Errorf("please provide a search query")
 	}
 
 	if session.CurrentLocation == uuid.Nil {
-		return fmt.Errorf("no location set, please set a location first")
+		return nil, fmt.Errorf("no location set, please set a location first")
 	}
 
 	query := args[0]
@@ -278,39 +237,139 @@ func (session *Session) SearchBooks(args ...string) error {
 		"location_id": session.CurrentLocation.String(),
 	})
 	if err != nil {
-		return fmt.Errorf("error marshalling request: %v", err)
+		return nil, fmt.Errorf("error marshalling request: %v", err)
 	}
 
 	req, err := http.NewRequest("GET", url, bytes.NewBuffer(reqBody))
 	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
+		return nil, fmt.Errorf("error creating request: %v", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return nil, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("error searching books: %v", res.Status)
+		return nil, fmt.Errorf("error searching books: %w", newAPIError(res))
 	}
 
 	var books []Book
 	if err := json.NewDecoder(res.Body).Decode(&books); err != nil {
-		return fmt.Errorf("error decoding response: %v", err)
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	return books, nil
+}
+
+func (session *Session) UpdateBookShelf(args ...string) error {
+	err := validateLoggedIn(session)
+	if err != nil {
+		return err
+	}
+
+	if len(args) < 2 {
+		return fmt.Errorf("please provide a book ID and a shelf ID")
+	}
+
+	bookID, err := uuid.Parse(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid book ID: %v", err)
+	}
+
+	shelfID, err := uuid.Parse(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid shelf ID: %v", err)
+	}
+
+	err = session.validateShelf(shelfID.String())
+	if err != nil {
+		return err
+	}
+
+	url := session.BaseURL + "books/" + bookID.String()
+
+	reqBody, err := json.Marshal(map[string]string{"shelf_id": shelfID.String()})
+	if err != nil {
+		return fmt.Errorf("error marshalling request: %v", err)
+	}
+
+	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	res, err := session.do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	return fmt.Errorf("error updating book shelf: %w", newAPIError(res))
+}
+
+// UpdateBook sends a partial update of a book: only the fields in changes,
+// keyed by their JSON names, are modified.
+func (session *Session) UpdateBook(bookID uuid.UUID, changes map[string]any) error {
+	err := validateLoggedIn(session)
+	if err != nil {
+		return err
+	}
+
+	url := session.BaseURL + "books/" + bookID.String()
+
+	reqBody, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("error marshalling request: %v", err)
+	}
+
+	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
 	}
 
-	for _, book := range books {
-		fmt.Printf("Title: %s\n", book.Title)
-		fmt.Printf("Author: %s\n", book.Author)
-		fmt.Printf("Genre: %s\n", book.Genre)
-		fmt.Printf("Publication Date: %s\n", book.PublicationDate)
-		fmt.Println()
+	req.Header.Set("Content-Type", "application/json")
+	res, err := session.do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	return fmt.Errorf("error updating book: %w", newAPIError(res))
+}
+
+func (session *Session) DeleteBook(bookID uuid.UUID) error {
+	err := validateLoggedIn(session)
+	if err != nil {
+		return err
+	}
+
+	url := session.BaseURL + "books/" + bookID.String()
+
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	res, err := session.do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNoContent || res.StatusCode == http.StatusOK {
+		return nil
 	}
 
-	return nil
+	return fmt.Errorf("error deleting book: %w", newAPIError(res))
 }
@@ -9,18 +9,18 @@ import (
 	"github.com/google/uuid"
 )
 
-func (session *Session) CreateCase(args ...string) error {
+func (session *Session) CreateCase(args ...string) (Case, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return err
+		return Case{}, err
 	}
 
 	if len(args) < 1 {
-		return fmt.Errorf("missing case name")
+		return Case{}, fmt.Errorf("missing case name")
 	}
 
 	if session.CurrentLocation == uuid.Nil {
-		return fmt.Errorf("no location set - please set a location")
+		return Case{}, fmt.Errorf("no location set - please set a location")
 	}
 
 	url := session.BaseURL + "cases"
@@ -37,104 +37,175 @@ func (session *Session) CreateCase(args ...string) error {
 
 	requestBody, err := json.Marshal(params)
 	if err != nil {
-		return err
+		return Case{}, err
 	}
 
 	req, err := http.NewRequest("POST", url, bytes.NewBuffer(requestBody))
 	if err != nil {
-		return err
+		return Case{}, err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		fmt.Println("error making request: ", err)
+		return Case{}, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == http.StatusCreated {
-		fmt.Println("Case created successfully")
-		return nil
-	} else {
-		return fmt.Errorf("error creating case")
+	if res.StatusCode != http.StatusCreated {
+		return Case{}, fmt.Errorf("error creating case: %w", newAPIError(res))
 	}
+
+	var c Case
+	err = json.NewDecoder(res.Body).Decode(&c)
+	if err != nil {
+		return Case{}, err
+	}
+	return c, nil
 }
 
-func (session *Session) GetCases() error {
+func (session *Session) GetCases() ([]Case, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if session.CurrentLocation == uuid.Nil {
-		return fmt.Errorf("no location set - please set a location")
+		return nil, fmt.Errorf("no location set - please set a location")
+	}
+	return session.GetLocationCases(session.CurrentLocation)
+}
+
+// GetLocationCases lists the cases in any location the user is a member of.
+func (session *Session) GetLocationCases(locationID uuid.UUID) ([]Case, error) {
+	err := validateLoggedIn(session)
+	if err != nil {
+		return nil, err
 	}
 
-	url := session.BaseURL + "locations/" + session.CurrentLocation.String() + "/cases"
+	url := session.BaseURL + "locations/" + locationID.String() + "/cases"
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		fmt.Println("error making request: ", err)
+		return nil, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == http.StatusOK {
-		var cases []Case
-		err = json.NewDecoder(res.Body).Decode(&cases)
-		if err != nil {
-			return err
-		}
-
-		for _, c := range cases {
-			fmt.Printf("Case Name: %s, Case ID: %s\n", c.Name, c.ID)
-		}
-		return nil
-	} else {
-		return fmt.Errorf("error getting cases")
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting cases: %w", newAPIError(res))
 	}
+
+	var cases []Case
+	err = json.NewDecoder(res.Body).Decode(&cases)
+	if err != nil {
+		return nil, err
+	}
+	return cases, nil
 }
 
-func (session *Session) ValidateCase(caseID string) error {
+func (session *Session) GetCase(caseID string) (Case, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return err
+		return Case{}, err
 	}
 
 	url := session.BaseURL + "cases/" + caseID
 
 	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return Case{}, err
+	}
+
+	res, err := session.do(req)
+	if err != nil {
+		return Case{}, fmt.Errorf("error making request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return Case{}, fmt.Errorf("error getting case: %w", newAPIError(res))
+	}
+
+	var c Case
+	err = json.NewDecoder(res.Body).Decode(&c)
+	if err != nil {
+		return Case{}, err
+	}
+	return c, nil
+}
+
+func (session *Session) ValidateCase(caseID string) error {
+	c, err := session.GetCase(caseID)
+	if err != nil {
+		return err
+	}
+
+	if c.LocationID != session.CurrentLocation {
+		return fmt.Errorf("case is not at the current location")
+	}
+	return nil
+}
+
+// UpdateCase sends a partial update of a case: only the fields in changes,
+// keyed by their JSON names, are modified.
+func (session *Session) UpdateCase(caseID uuid.UUID, changes map[string]any) error {
+	err := validateLoggedIn(session)
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+session.Token)
+	url := session.BaseURL + "cases/" + caseID.String()
 
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	reqBody, err := json.Marshal(changes)
 	if err != nil {
-		fmt.Println("error making request: ", err)
+		return fmt.Errorf("error marshalling request: %v", err)
+	}
+
+	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	res, err := session.do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode == http.StatusOK {
-		var c Case
-		err = json.NewDecoder(res.Body).Decode(&c)
-		if err != nil {
-			return err
-		}
-
-		if c.LocationID != session.CurrentLocation {
-			return fmt.Errorf("case is not at the current location")
-		}
 		return nil
-	} else {
-		return fmt.Errorf("case not found")
 	}
+
+	return fmt.Errorf("error updating case: %w", newAPIError(res))
+}
+
+func (session *Session) DeleteCase(caseID uuid.UUID) error {
+	err := validateLoggedIn(session)
+	if err != nil {
+		return err
+	}
+
+	url := session.BaseURL + "cases/" + caseID.String()
+
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	res, err := session.do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNoContent || res.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	return fmt.Errorf("error deleting case: %w", newAPIError(res))
 }
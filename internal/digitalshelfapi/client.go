@@ -1,10 +1,16 @@
 package digitalshelfapi
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"net/http"
+	"strings"
 	"time"
 )
 
+// tokenRefreshMargin is how long before expiry the access token is renewed.
+const tokenRefreshMargin = 30 * time.Second
+
 // NewClient -
 func NewClient(timeout time.Duration) Client {
 	return Client{
@@ -13,3 +19,111 @@ func NewClient(timeout time.Duration) Client {
 		},
 	}
 }
+
+// do sends a request authenticated with the session's access token. The
+// token is refreshed when it is about to expire, or when the server rejects
+// it, in which case the request is retried once with the new token.
+func (session *Session) do(req *http.Request) (*http.Response, error) {
+	token, err := session.accessToken()
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	res, err := session.DSAPIClient.HttpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	_, refreshToken := session.Tokens()
+	if res.StatusCode != http.StatusUnauthorized || refreshToken == "" {
+		return res, nil
+	}
+	if req.Body != nil && req.GetBody == nil {
+		return res, nil
+	}
+	res.Body.Close()
+
+	token, err = session.refresh(token)
+	if err != nil {
+		return nil, err
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		retry.Body, err = req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+	}
+	retry.Header.Set("Authorization", "Bearer "+token)
+
+	return session.DSAPIClient.HttpClient.Do(retry)
+}
+
+// accessToken returns the current access token, refreshing it first if it
+// expires within tokenRefreshMargin.
+func (session *Session) accessToken() (string, error) {
+	token, refreshToken := session.Tokens()
+	expiresAt, ok := tokenExpiry(token)
+	if !ok || refreshToken == "" || time.Until(expiresAt) > tokenRefreshMargin {
+		return token, nil
+	}
+	return session.refresh(token)
+}
+
+// refresh swaps stale for a new access token. If another request already
+// refreshed the token, the new one is returned without asking the server.
+func (session *Session) refresh(stale string) (string, error) {
+	session.tokenMu.Lock()
+	defer session.tokenMu.Unlock()
+
+	if session.Token != stale && session.Token != "" {
+		return session.Token, nil
+	}
+	err := session.refreshAccessToken()
+	if err != nil {
+		return "", err
+	}
+	return session.Token, nil
+}
+
+// Tokens returns the access and refresh tokens.
+func (session *Session) Tokens() (token, refreshToken string) {
+	session.tokenMu.Lock()
+	defer session.tokenMu.Unlock()
+	return session.Token, session.RefreshToken
+}
+
+// SetTokens replaces the access and refresh tokens, as when a saved session
+// is restored.
+func (session *Session) SetTokens(token, refreshToken string) {
+	session.tokenMu.Lock()
+	defer session.tokenMu.Unlock()
+	session.Token = token
+	session.RefreshToken = refreshToken
+}
+
+// LoggedIn reports whether the session has an access token.
+func (session *Session) LoggedIn() bool {
+	token, _ := session.Tokens()
+	return token != ""
+}
+
+// tokenExpiry reads the exp claim of a JWT without verifying it.
+func tokenExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	var claims struct {
+		ExpiresAt int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.ExpiresAt == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(claims.ExpiresAt, 0), true
+}
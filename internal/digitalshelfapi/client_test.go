@@ -0,0 +1,119 @@
+package digitalshelfapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestDoRefreshesExpiredToken(t *testing.T) {
+	shelfID := uuid.New()
+	refreshes := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/refresh":
+			refreshes++
+			if r.Header.Get("Authorization") != "Bearer refresh-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"token": "new-token"})
+		case "/shelves/" + shelfID.String():
+			if r.Header.Get("Authorization") != "Bearer new-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			json.NewEncoder(w).Encode(Shelf{ID: shelfID, Name: "Top"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	session := Session{
+		DSAPIClient:  NewClient(time.Second),
+		BaseURL:      server.URL + "/",
+		Token:        "old-token",
+		RefreshToken: "refresh-token",
+	}
+
+	shelf, err := session.GetShelf(shelfID.String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if shelf.Name != "Top" || session.Token != "new-token" || refreshes != 1 {
+		t.Errorf("expected one refresh and the retried shelf, got %+v token=%q refreshes=%d", shelf, session.Token, refreshes)
+	}
+
+	session.Token = "old-token"
+	session.RefreshToken = "revoked"
+	_, err = session.GetShelf(shelfID.String())
+	if !errors.Is(err, ErrSessionExpired) {
+		t.Errorf("expected ErrSessionExpired, got %v", err)
+	}
+	if session.Token != "" || session.RefreshToken != "" {
+		t.Errorf("expected the session to be cleared, got token=%q refresh token=%q", session.Token, session.RefreshToken)
+	}
+}
+
+// TestDoRefreshesOnceForConcurrentRequests sends requests from several
+// goroutines with a stale token. Run with -race to check the tokens are
+// only touched under the session's lock.
+func TestDoRefreshesOnceForConcurrentRequests(t *testing.T) {
+	shelfID := uuid.New()
+	var refreshes atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/refresh":
+			refreshes.Add(1)
+			json.NewEncoder(w).Encode(map[string]string{"token": "new-token"})
+		case "/shelves/" + shelfID.String():
+			if r.Header.Get("Authorization") != "Bearer new-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			json.NewEncoder(w).Encode(Shelf{ID: shelfID, Name: "Top"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	session := &Session{
+		DSAPIClient:  NewClient(time.Second),
+		BaseURL:      server.URL + "/",
+		Token:        "old-token",
+		RefreshToken: "refresh-token",
+	}
+
+	const requests = 16
+	errs := make([]error, requests)
+	var wg sync.WaitGroup
+	for i := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = session.GetShelf(shelfID.String())
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("request %d: unexpected error: %v", i, err)
+		}
+	}
+	token, _ := session.Tokens()
+	if token != "new-token" || refreshes.Load() != 1 {
+		t.Errorf("expected one refresh to new-token, got token=%q refreshes=%d", token, refreshes.Load())
+	}
+}
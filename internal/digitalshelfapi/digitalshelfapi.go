@@ -2,6 +2,7 @@ package digitalshelfapi
 
 import (
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,22 +14,70 @@ type Client struct {
 }
 
 type Session struct {
-	DSAPIClient     Client
-	Platform        string
-	BaseURL         string
-	User            User
-	Token           string
-	RefreshToken    string
-	CurrentLocation uuid.UUID
-	CurrentShelf    uuid.UUID
+	DSAPIClient Client
+	Platform    string
+	BaseURL     string
+	User        User
+	// Token and RefreshToken are read and written under tokenMu, since
+	// requests may be sent from several goroutines. Use Tokens and
+	// SetTokens from outside the package.
+	Token               string
+	RefreshToken        string
+	tokenMu             sync.Mutex
+	CurrentLocation     uuid.UUID
+	CurrentShelf        uuid.UUID
+	CurrentLocationName string
+	CurrentCaseName     string
+	CurrentShelfName    string
+	// CurrentRole is the user's role in the current location, or "" if the
+	// server did not say.
+	CurrentRole string
 }
 
+// Roles a member can have in a location, from most to least access.
+const (
+	RoleOwner  = "owner"
+	RoleEditor = "editor"
+	RoleViewer = "viewer"
+)
+
 type User struct {
 	ID    uuid.UUID `json:"id"`
 	Name  string    `json:"name"`
 	Email string    `json:"email"`
 }
 
+type Location struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	OwnerID   uuid.UUID `json:"owner_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type LocationMembership struct {
+	LocationID   uuid.UUID `json:"location_id"`
+	LocationName string    `json:"location_name"`
+	OwnerID      uuid.UUID `json:"owner_id"`
+	JoinedAt     time.Time `json:"joined_at"`
+}
+
+type LocationMember struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Name     string    `json:"name"`
+	Email    string    `json:"email"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+type UserInvite struct {
+	UserID       uuid.UUID `json:"userID"`
+	LocationID   uuid.UUID `json:"location_id"`
+	LocationName string    `json:"location_name"`
+	OwnerID      uuid.UUID `json:"owner_id"`
+	InvitedAt    time.Time `json:"invited_at"`
+}
+
 type Case struct {
 	ID         uuid.UUID `json:"id"`
 	Name       string    `json:"name"`
@@ -0,0 +1,89 @@
+package digitalshelfapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors for use with errors.Is. An *APIError matches the sentinel
+// that corresponds to its status code.
+var (
+	ErrNotLoggedIn  = errors.New("you must be logged in to do that")
+	ErrBadRequest   = errors.New("bad request")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrServer       = errors.New("server error")
+
+	// ErrSessionExpired is returned when the access token could not be
+	// refreshed. The session has been cleared by the time it is returned.
+	ErrSessionExpired = errors.New("your session has expired, please log in again")
+)
+
+// APIError is returned when the DigitalShelf API responds with an unexpected
+// status code. Message holds the error reported by the server, if any.
+type APIError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	status := fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Message == "" {
+		return status
+	}
+	return fmt.Sprintf("%s (%s)", e.Message, status)
+}
+
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrServer:
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
+
+// newAPIError builds an *APIError from a response, reading the server's
+// error message from the body. The body is left for the caller to close.
+func newAPIError(res *http.Response) error {
+	apiErr := &APIError{
+		StatusCode: res.StatusCode,
+	}
+	if res.Request != nil {
+		apiErr.Method = res.Request.Method
+		apiErr.URL = res.Request.URL.String()
+	}
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, 4096))
+	if err != nil {
+		return apiErr
+	}
+
+	var errorBody struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(body, &errorBody) == nil && errorBody.Error != "" {
+		apiErr.Message = errorBody.Error
+	} else if !json.Valid(body) {
+		apiErr.Message = strings.TrimSpace(string(body))
+	}
+
+	return apiErr
+}
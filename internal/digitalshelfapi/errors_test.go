@@ -0,0 +1,73 @@
+package digitalshelfapi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestAPIError(t *testing.T) {
+	cases := []struct {
+		status   int
+		body     string
+		sentinel error
+		message  string
+	}{
+		{
+			status:   http.StatusNotFound,
+			body:     `{"error":"shelf not found"}`,
+			sentinel: ErrNotFound,
+			message:  "shelf not found",
+		},
+		{
+			status:   http.StatusForbidden,
+			body:     `{"error":"not a member of this location"}`,
+			sentinel: ErrForbidden,
+			message:  "not a member of this location",
+		},
+		{
+			status:   http.StatusUnauthorized,
+			body:     ``,
+			sentinel: ErrUnauthorized,
+			message:  "",
+		},
+		{
+			status:   http.StatusBadGateway,
+			body:     "upstream unavailable",
+			sentinel: ErrServer,
+			message:  "upstream unavailable",
+		},
+	}
+
+	for _, c := range cases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(c.status)
+			w.Write([]byte(c.body))
+		}))
+
+		session := Session{
+			DSAPIClient: NewClient(time.Second),
+			BaseURL:     server.URL + "/",
+			Token:       "token",
+		}
+		_, err := session.GetShelf(uuid.NewString())
+		server.Close()
+
+		if !errors.Is(err, c.sentinel) {
+			t.Errorf("status %d: expected errors.Is(%v, %v)", c.status, err, c.sentinel)
+			continue
+		}
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Errorf("status %d: expected an *APIError, got %T", c.status, err)
+			continue
+		}
+		if apiErr.StatusCode != c.status || apiErr.Message != c.message || apiErr.Method != "GET" {
+			t.Errorf("status %d: unexpected APIError %+v", c.status, apiErr)
+		}
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"time"
 
 	"github.com/google/uuid"
 )
@@ -50,64 +49,49 @@ func (session *Session) InviteUser(args ...string) error {
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return fmt.Errorf("error making request: %w", err)
 	}
+	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusCreated {
-		return fmt.Errorf("error inviting user: %v", res.Status)
+		return fmt.Errorf("error inviting user: %w", newAPIError(res))
 	}
 
-	fmt.Println("User invited successfully")
-
 	return nil
 }
 
-func (session *Session) GetUserInvites() error {
+func (session *Session) GetUserInvites() ([]UserInvite, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	url := session.BaseURL + "/users/" + session.User.ID.String() + "/invites"
 
-	type UserInvite []struct {
-		UserID       uuid.UUID `json:"userID"`
-		LocationID   uuid.UUID `json:"location_id"`
-		LocationName string    `json:"location_name"`
-		OwnerID      uuid.UUID `json:"owner_id"`
-		InvitedAt    time.Time `json:"invited_at"`
-	}
-
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	defer res.Body.Close()
 
-	if res.StatusCode == http.StatusOK {
-		var invites UserInvite
-		json.NewDecoder(res.Body).Decode(&invites)
-
-		fmt.Println("User Invites:")
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting user invites: %w", newAPIError(res))
+	}
 
-		for _, invite := range invites {
-			fmt.Printf("Location Name: %s, Location ID: %s, Invited At: %s\n", invite.LocationName, invite.LocationID.String(), invite.InvitedAt.String())
-		}
-		return nil
+	var invites []UserInvite
+	err = json.NewDecoder(res.Body).Decode(&invites)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
 	}
-	return fmt.Errorf("error getting user invites")
+	return invites, nil
 }
 
 func (session *Session) RemoveUserInvite(args ...string) error {
@@ -135,18 +119,41 @@ func (session *Session) RemoveUserInvite(args ...string) error {
 	if err != nil {
 		return fmt.Errorf("error creating request: %v", err)
 	}
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode == http.StatusNoContent {
-		fmt.Println("Invite removed successfully")
 		return nil
 	}
 
-	return fmt.Errorf("error removing invite: %v", res.Status)
+	return fmt.Errorf("error removing invite: %w", newAPIError(res))
+}
+
+// DeclineInvite turns down the user's invite to a location.
+func (session *Session) DeclineInvite(locationID uuid.UUID) error {
+	err := validateLoggedIn(session)
+	if err != nil {
+		return err
+	}
+
+	url := session.BaseURL + "locations/" + locationID.String() + "/invites/" + session.User.ID.String()
+
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+	res, err := session.do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNoContent || res.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	return fmt.Errorf("error declining invite: %w", newAPIError(res))
 }
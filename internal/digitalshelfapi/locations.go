@@ -5,58 +5,49 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"time"
 
 	"github.com/google/uuid"
 )
 
-func (session *Session) GetUserLocations(args ...string) error {
+func (session *Session) GetUserLocations(args ...string) ([]LocationMembership, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	url := session.BaseURL + "users/" + session.User.ID.String() + "/locations"
 
-	type locationMembership []struct {
-		LocationID   string    `json:"location_id"`
-		LocationName string    `json:"location_name"`
-		OwnerID      string    `json:"owner_id"`
-		JoinedAt     time.Time `json:"joined_at"`
-	}
-
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		fmt.Println("error making request: ", err)
+		return nil, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
-	if res.StatusCode == http.StatusOK {
-		var locations locationMembership
-		err = json.NewDecoder(res.Body).Decode(&locations)
-		if err != nil {
-			return err
-		}
-		fmt.Println("Locations:")
-		for _, location := range locations {
-			fmt.Printf("Location Name: %s, Location ID: %s\n", location.LocationName, location.LocationID)
-		}
-		return nil
-	} else {
-		return fmt.Errorf("error getting user locations")
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting user locations: %w", newAPIError(res))
 	}
+
+	var locations []LocationMembership
+	err = json.NewDecoder(res.Body).Decode(&locations)
+	if err != nil {
+		return nil, err
+	}
+	return locations, nil
 }
 
-func (session *Session) CreateLocation(args ...string) error {
+func (session *Session) CreateLocation(args ...string) (Location, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return err
+		return Location{}, err
+	}
+
+	if len(args) < 1 {
+		return Location{}, fmt.Errorf("missing location name")
 	}
 
 	url := session.BaseURL + "locations"
@@ -69,41 +60,31 @@ func (session *Session) CreateLocation(args ...string) error {
 		OwnerID: session.User.ID,
 	}
 
-	type response struct {
-		ID        uuid.UUID `json:"id"`
-		Name      string    `json:"name"`
-		OwnerID   uuid.UUID `json:"owner_id"`
-		CreatedAt time.Time `json:"created_at"`
-		UpdatedAt time.Time `json:"updated_at"`
-	}
-
 	request_data, err := json.Marshal(params)
 	if err != nil {
-		return err
+		return Location{}, err
 	}
 	req, err := http.NewRequest("POST", url, bytes.NewBuffer(request_data))
 	if err != nil {
-		return err
+		return Location{}, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		fmt.Println("error making request: ", err)
+		return Location{}, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
-	if res.StatusCode == http.StatusCreated {
-		var response response
-		err = json.NewDecoder(res.Body).Decode(&response)
-		if err != nil {
-			return err
-		}
-		fmt.Printf("Location created successfully. Be sure to join it with its ID.\n New Location ID: %s\n", response.ID)
-		return nil
-	} else {
-		return fmt.Errorf("error creating location")
+
+	if res.StatusCode != http.StatusCreated {
+		return Location{}, fmt.Errorf("error creating location: %w", newAPIError(res))
 	}
+
+	var location Location
+	err = json.NewDecoder(res.Body).Decode(&location)
+	if err != nil {
+		return Location{}, err
+	}
+	return location, nil
 }
 
 func (session *Session) JoinLocaion(args ...string) error {
@@ -112,6 +93,10 @@ func (session *Session) JoinLocaion(args ...string) error {
 		return err
 	}
 
+	if len(args) < 1 {
+		return fmt.Errorf("missing location ID")
+	}
+
 	url := session.BaseURL + "locations/" + args[0] + "/members"
 	type parameters struct {
 		UserID uuid.UUID `json:"user_id"`
@@ -130,65 +115,208 @@ func (session *Session) JoinLocaion(args ...string) error {
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode == http.StatusCreated {
-		fmt.Println("Joined successfully")
 		return nil
-	} else if res.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("location not found")
-	} else {
-		return fmt.Errorf("error adding member to location")
 	}
+
+	return fmt.Errorf("error adding member to location: %w", newAPIError(res))
 }
 
-func (session *Session) SetCurrentLocation(args ...string) error {
+func (session *Session) GetLocation(args ...string) (Location, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return err
+		return Location{}, err
 	}
 
 	if len(args) < 1 {
-		return fmt.Errorf("missing location ID")
+		return Location{}, fmt.Errorf("missing location ID")
 	}
 
 	url := session.BaseURL + "locations/" + args[0]
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
+		return Location{}, fmt.Errorf("error creating request: %v", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return Location{}, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("location not found")
+		return Location{}, fmt.Errorf("error getting location: %w", newAPIError(res))
 	}
 
-	var location struct {
-		ID uuid.UUID `json:"id"`
-	}
+	var location Location
 	err = json.NewDecoder(res.Body).Decode(&location)
 	if err != nil {
-		return fmt.Errorf("error decoding response: %v", err)
+		return Location{}, fmt.Errorf("error decoding response: %v", err)
+	}
+	return location, nil
+}
+
+func (session *Session) SetCurrentLocation(args ...string) (Location, error) {
+	location, err := session.GetLocation(args...)
+	if err != nil {
+		return Location{}, err
+	}
+
+	if location.ID != session.CurrentLocation {
+		session.CurrentShelf = uuid.Nil
+		session.CurrentCaseName = ""
+		session.CurrentShelfName = ""
 	}
 	session.CurrentLocation = location.ID
-	fmt.Printf("Location set to: %s\n", args[0])
+	session.CurrentLocationName = location.Name
+	session.CurrentRole = session.roleIn(location)
+	return location, nil
+}
+
+// roleIn returns the user's role in a location. Servers that do not report
+// roles leave it empty, except that the owner is always known.
+func (session *Session) roleIn(location Location) string {
+	if location.OwnerID == session.User.ID {
+		return RoleOwner
+	}
+	members, err := session.GetLocationMembers(location.ID)
+	if err != nil {
+		return ""
+	}
+	for _, member := range members {
+		if member.UserID == session.User.ID {
+			return member.Role
+		}
+	}
+	return ""
+}
+
+func (session *Session) GetLocationMembers(locationID uuid.UUID) ([]LocationMember, error) {
+	err := validateLoggedIn(session)
+	if err != nil {
+		return nil, err
+	}
+
+	url := session.BaseURL + "locations/" + locationID.String() + "/members"
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	res, err := session.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting location members: %w", newAPIError(res))
+	}
+
+	var members []LocationMember
+	err = json.NewDecoder(res.Body).Decode(&members)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+	return members, nil
+}
+
+// SetMemberRole changes the role of a member of the current location.
+func (session *Session) SetMemberRole(userID uuid.UUID, role string) error {
+	err := validateLoggedIn(session)
+	if err != nil {
+		return err
+	}
+
+	if session.CurrentLocation == uuid.Nil {
+		return fmt.Errorf("no location set - please set a location")
+	}
+
+	url := session.BaseURL + "locations/" + session.CurrentLocation.String() + "/members/" + userID.String()
+
+	reqBody, err := json.Marshal(map[string]string{"role": role})
+	if err != nil {
+		return fmt.Errorf("error marshalling request: %v", err)
+	}
+
+	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	res, err := session.do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	return fmt.Errorf("error setting member role: %w", newAPIError(res))
+}
+
+// TransferLocation makes another member the owner of a location.
+func (session *Session) TransferLocation(locationID, userID uuid.UUID) error {
+	err := session.UpdateLocation(locationID, map[string]any{"owner_id": userID})
+	if err != nil {
+		return err
+	}
+	if locationID == session.CurrentLocation {
+		_, err = session.SetCurrentLocation(locationID.String())
+	}
+	return err
+}
+
+// LeaveLocation removes the user from a location's members.
+func (session *Session) LeaveLocation(locationID uuid.UUID) error {
+	err := validateLoggedIn(session)
+	if err != nil {
+		return err
+	}
+
+	url := session.BaseURL + "locations/" + locationID.String() + "/members/" + session.User.ID.String()
+
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+	res, err := session.do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK {
+		return fmt.Errorf("error leaving location: %w", newAPIError(res))
+	}
+
+	if locationID == session.CurrentLocation {
+		session.clearLocation()
+	}
 	return nil
 }
 
+// clearLocation forgets the current location and shelf.
+func (session *Session) clearLocation() {
+	session.CurrentLocation = uuid.Nil
+	session.CurrentShelf = uuid.Nil
+	session.CurrentLocationName = ""
+	session.CurrentCaseName = ""
+	session.CurrentShelfName = ""
+	session.CurrentRole = ""
+}
+
 func (session *Session) RemoveLocationMember(args ...string) error {
 	err := validateLoggedIn(session)
 	if err != nil {
@@ -201,24 +329,82 @@ func (session *Session) RemoveLocationMember(args ...string) error {
 
 	url := session.BaseURL + "locations/" + session.CurrentLocation.String() + "/members/" + args[0]
 
-	fmt.Println(url)
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+	res, err := session.do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	return fmt.Errorf("error removing member from location: %w", newAPIError(res))
+}
+
+// UpdateLocation sends a partial update of a location: only the fields in changes,
+// keyed by their JSON names, are modified.
+func (session *Session) UpdateLocation(locationID uuid.UUID, changes map[string]any) error {
+	err := validateLoggedIn(session)
+	if err != nil {
+		return err
+	}
+
+	url := session.BaseURL + "locations/" + locationID.String()
+
+	reqBody, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("error marshalling request: %v", err)
+	}
+
+	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	res, err := session.do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	return fmt.Errorf("error updating location: %w", newAPIError(res))
+}
+
+func (session *Session) DeleteLocation(locationID uuid.UUID) error {
+	err := validateLoggedIn(session)
+	if err != nil {
+		return err
+	}
+
+	url := session.BaseURL + "locations/" + locationID.String()
 
 	req, err := http.NewRequest("DELETE", url, nil)
 	if err != nil {
 		return fmt.Errorf("error creating request: %v", err)
 	}
-	req.Header.Set("Authorization", "Bearer "+session.Token)
 
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == http.StatusNoContent {
-		fmt.Println("Member removed successfully")
+	if res.StatusCode == http.StatusNoContent || res.StatusCode == http.StatusOK {
+		if locationID == session.CurrentLocation {
+			session.clearLocation()
+		}
 		return nil
 	}
 
-	return fmt.Errorf("error removing member from location: %v", res.Status)
+	return fmt.Errorf("error deleting location: %w", newAPIError(res))
 }
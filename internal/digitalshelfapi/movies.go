@@ -29,34 +29,20 @@ func (session *Session) LookupMovieBarcode(args ...string) (Movie, error) {
 		return Movie{}, fmt.Errorf("error creating request: %v", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return Movie{}, fmt.Errorf("error making request: %v", err)
+		return Movie{}, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == http.StatusNotFound {
-		return Movie{}, fmt.Errorf("movie not found")
-	}
-
 	if res.StatusCode != http.StatusOK {
-		return Movie{}, fmt.Errorf("error looking up movie: %v", res.Status)
+		return Movie{}, fmt.Errorf("error looking up movie: %w", newAPIError(res))
 	}
 
 	var movie Movie
 	if err := json.NewDecoder(res.Body).Decode(&movie); err != nil {
 		return Movie{}, fmt.Errorf("error decoding response: %v", err)
 	}
-	fmt.Println("Movie found")
-	fmt.Printf("Title: %s\n", movie.Title)
-	fmt.Printf("Genre: %s\n", movie.Genre)
-	fmt.Printf("Actors: %s\n", movie.Actors)
-	fmt.Printf("Writer: %s\n", movie.Writer)
-	fmt.Printf("Director: %s\n", movie.Director)
-	fmt.Printf("Format: %s\n", movie.Format)
-	fmt.Printf("Release Date: %s\n", movie.ReleaseDate)
 
 	return movie, nil
 }
@@ -104,30 +90,27 @@ func (session *Session) AddMovie(shelfID uuid.UUID, movie Movie) error {
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode == http.StatusCreated {
-		fmt.Println("Movie added successfully")
 		return nil
 	}
 
-	return fmt.Errorf("error adding movie: %v", res.Status)
+	return fmt.Errorf("error adding movie: %w", newAPIError(res))
 }
 
-func (session *Session) GetMovies(args ...string) error {
+func (session *Session) GetMovies(args ...string) ([]Movie, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(args) < 1 {
-		return fmt.Errorf("please specify a shelf ID")
+		return nil, fmt.Errorf("please specify a shelf ID")
 	}
 
 	shelfID := args[0]
@@ -136,153 +119,119 @@ func (session *Session) GetMovies(args ...string) error {
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
+		return nil, fmt.Errorf("error creating request: %v", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return nil, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("error getting movies: %v", res.Status)
+		return nil, fmt.Errorf("error getting movies: %w", newAPIError(res))
 	}
 
 	var movies []Movie
 	if err := json.NewDecoder(res.Body).Decode(&movies); err != nil {
-		return fmt.Errorf("error decoding response: %v", err)
+		return nil, fmt.Errorf("error decoding response: %v", err)
 	}
 
-	for _, movie := range movies {
-		fmt.Printf("ID: %s\n", movie.ID)
-		fmt.Printf("Title: %s\n", movie.Title)
-		fmt.Printf("Genre: %s\n", movie.Genre)
-		fmt.Printf("Actors: %s\n", movie.Actors)
-		fmt.Printf("Writer: %s\n", movie.Writer)
-		fmt.Printf("Director: %s\n", movie.Director)
-		fmt.Printf("Format: %s\n", movie.Format)
-		fmt.Printf("Release Date: %s\n", movie.ReleaseDate)
-		fmt.Println()
-	}
-
-	return nil
+	return movies, nil
 }
 
-func (session *Session) GetAllLocationMovies(args ...string) error {
+func (session *Session) GetAllLocationMovies(args ...string) ([]Movie, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return fmt.Errorf("you must be logged in to do that")
+		return nil, err
 	}
 
 	if len(args) < 1 {
-		return fmt.Errorf("please specify a location ID")
+		return nil, fmt.Errorf("please specify a location ID")
 	}
 
 	locationID, err := uuid.Parse(args[0])
 	if err != nil {
-		return fmt.Errorf("invalid location ID: %v", err)
+		return nil, fmt.Errorf("invalid location ID: %v", err)
 	}
 
 	url := session.BaseURL + "locations/" + locationID.String() + "/movies"
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
+		return nil, fmt.Errorf("error creating request: %v", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return nil, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("error getting movies: %v", res.Status)
+		return nil, fmt.Errorf("error getting movies: %w", newAPIError(res))
 	}
 
 	var movies []Movie
 	if err := json.NewDecoder(res.Body).Decode(&movies); err != nil {
-		return fmt.Errorf("error decoding response: %v", err)
+		return nil, fmt.Errorf("error decoding response: %v", err)
 	}
 
-	for _, movie := range movies {
-		fmt.Printf("ID: %s\n", movie.ID)
-		fmt.Printf("Title: %s\n", movie.Title)
-		fmt.Printf("Release Date: %s\n", movie.ReleaseDate)
-		fmt.Printf("Format: %s\n", movie.Format)
-		fmt.Println()
-	}
-	return nil
+	return movies, nil
 }
 
-func (session *Session) GetMovie(args ...string) error {
+func (session *Session) GetMovie(args ...string) (Movie, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return err
+		return Movie{}, err
 	}
 
 	if len(args) < 1 {
-		return fmt.Errorf("please specify a movie ID")
+		return Movie{}, fmt.Errorf("please specify a movie ID")
 	}
 
 	movieUUID, err := uuid.Parse(args[0])
 	if err != nil {
-		return fmt.Errorf("invalid movie ID: %v", err)
+		return Movie{}, fmt.Errorf("invalid movie ID: %v", err)
 	}
 
 	url := session.BaseURL + "movies/" + movieUUID.String()
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
+		return Movie{}, fmt.Errorf("error creating request: %v", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return Movie{}, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("error getting movie: %v", res.Status)
+		return Movie{}, fmt.Errorf("error getting movie: %w", newAPIError(res))
 	}
 
 	var movie Movie
 	if err := json.NewDecoder(res.Body).Decode(&movie); err != nil {
-		return fmt.Errorf("error decoding response: %v", err)
+		return Movie{}, fmt.Errorf("error decoding response: %v", err)
 	}
 
-	fmt.Printf("Title: %s\n", movie.Title)
-	fmt.Printf("Genre: %s\n", movie.Genre)
-	fmt.Printf("Actors: %s\n", movie.Actors)
-	fmt.Printf("Writer: %s\n", movie.Writer)
-	fmt.Printf("Director: %s\n", movie.Director)
-	fmt.Printf("Release Date: %s\n", movie.ReleaseDate)
-	fmt.Printf("Barcode: %s\n", movie.Barcode)
-	fmt.Printf("Format: %s\n", movie.Format)
-
-	return nil
+	return movie, nil
 }
 
-func (session *Session) SearchMovies(args ...string) error {
+func (session *Session) SearchMovies(args ...string) ([]Movie, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(args) < 1 {
-		return fmt.Errorf("please provide a search query")
+		return nil, fmt.Errorf("please provide a search query")
 	}
 
 	if session.CurrentLocation == uuid.Nil {
-		return fmt.Errorf("no location set, please set a location first")
+		return nil, fmt.Errorf("no location set, please set a location first")
 	}
 
 	query := args[0]
@@ -294,44 +243,31 @@ func (session *Session) SearchMovies(args ...string) error {
 		"location_id": session.CurrentLocation.String(),
 	})
 	if err != nil {
-		return fmt.Errorf("error marshalling request: %v", err)
+		return nil, fmt.Errorf("error marshalling request: %v", err)
 	}
 
 	req, err := http.NewRequest("GET", url, bytes.NewBuffer(reqBody))
 	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
+		return nil, fmt.Errorf("error creating request: %v", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return nil, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("error searching movies: %v", res.Status)
+		return nil, fmt.Errorf("error searching movies: %w", newAPIError(res))
 	}
 
 	var movies []Movie
 	if err := json.NewDecoder(res.Body).Decode(&movies); err != nil {
-		return fmt.Errorf("error decoding response: %v", err)
-	}
-
-	for _, movie := range movies {
-		fmt.Printf("Title: %s\n", movie.Title)
-		fmt.Printf("Genre: %s\n", movie.Genre)
-		fmt.Printf("Actors: %s\n", movie.Actors)
-		fmt.Printf("Writer: %s\n", movie.Writer)
-		fmt.Printf("Director: %s\n", movie.Director)
-		fmt.Printf("Release Date: %s\n", movie.ReleaseDate)
-		fmt.Printf("Format: %s\n", movie.Format)
-		fmt.Println()
+		return nil, fmt.Errorf("error decoding response: %v", err)
 	}
 
-	return nil
+	return movies, nil
 }
 
 func (session *Session) UpdateMovieShelf(args ...string) error {
@@ -371,18 +307,75 @@ func (session *Session) UpdateMovieShelf(args ...string) error {
 		return fmt.Errorf("error creating request: %v", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+session.Token)
+	res, err := session.do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	return fmt.Errorf("error updating movie shelf: %w", newAPIError(res))
+}
 
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+// UpdateMovie sends a partial update of a movie: only the fields in changes,
+// keyed by their JSON names, are modified.
+func (session *Session) UpdateMovie(movieID uuid.UUID, changes map[string]any) error {
+	err := validateLoggedIn(session)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return err
+	}
+
+	url := session.BaseURL + "movies/" + movieID.String()
+
+	reqBody, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("error marshalling request: %v", err)
+	}
+
+	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	res, err := session.do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode == http.StatusOK {
-		fmt.Println("Movie shelf updated successfully")
 		return nil
 	}
 
-	return fmt.Errorf("error updating movie shelf: %v", res.Status)
+	return fmt.Errorf("error updating movie: %w", newAPIError(res))
+}
+
+func (session *Session) DeleteMovie(movieID uuid.UUID) error {
+	err := validateLoggedIn(session)
+	if err != nil {
+		return err
+	}
+
+	url := session.BaseURL + "movies/" + movieID.String()
+
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	res, err := session.do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNoContent || res.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	return fmt.Errorf("error deleting movie: %w", newAPIError(res))
 }
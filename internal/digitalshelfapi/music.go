@@ -29,32 +29,20 @@ func (session *Session) LookupMusicBarcode(args ...string) (Music, error) {
 		return Music{}, fmt.Errorf("error creating request: %v", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return Music{}, fmt.Errorf("error making request: %v", err)
+		return Music{}, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == http.StatusNotFound {
-		return Music{}, fmt.Errorf("music not found")
-	}
-
 	if res.StatusCode != http.StatusOK {
-		return Music{}, fmt.Errorf("error looking up music: %v", res.Status)
+		return Music{}, fmt.Errorf("error looking up music: %w", newAPIError(res))
 	}
 
 	var music Music
 	if err := json.NewDecoder(res.Body).Decode(&music); err != nil {
 		return Music{}, fmt.Errorf("error decoding response: %v", err)
 	}
-	fmt.Println("Music found")
-	fmt.Printf("Title: %s\n", music.Title)
-	fmt.Printf("Artist: %s\n", music.Artist)
-	fmt.Printf("Genre: %s\n", music.Genre)
-	fmt.Printf("Format: %s\n", music.Format)
-	fmt.Printf("Release Date: %s\n", music.ReleaseDate)
 
 	return music, nil
 }
@@ -98,30 +86,27 @@ func (session *Session) AddMusic(shelfID uuid.UUID, music Music) error {
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode == http.StatusCreated {
-		fmt.Println("Music added successfully")
 		return nil
 	}
 
-	return fmt.Errorf("error adding music: %v", res.Status)
+	return fmt.Errorf("error adding music: %w", newAPIError(res))
 }
 
-func (session *Session) GetMusic(args ...string) error {
+func (session *Session) GetMusic(args ...string) ([]Music, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(args) < 1 {
-		return fmt.Errorf("please specify a shelf ID")
+		return nil, fmt.Errorf("please specify a shelf ID")
 	}
 
 	shelfID := args[0]
@@ -130,150 +115,119 @@ func (session *Session) GetMusic(args ...string) error {
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
+		return nil, fmt.Errorf("error creating request: %v", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return nil, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("error getting music: %v", res.Status)
+		return nil, fmt.Errorf("error getting music: %w", newAPIError(res))
 	}
 
 	var musicList []Music
 	if err := json.NewDecoder(res.Body).Decode(&musicList); err != nil {
-		return fmt.Errorf("error decoding response: %v", err)
+		return nil, fmt.Errorf("error decoding response: %v", err)
 	}
 
-	for _, music := range musicList {
-		fmt.Printf("Title: %s\n", music.Title)
-		fmt.Printf("Artist: %s\n", music.Artist)
-		fmt.Printf("Genre: %s\n", music.Genre)
-		fmt.Printf("Format: %s\n", music.Format)
-		fmt.Printf("Release Date: %s\n", music.ReleaseDate)
-		fmt.Println()
-	}
-
-	return nil
+	return musicList, nil
 }
 
-func (session *Session) GetAllLocationMusic(args ...string) error {
+func (session *Session) GetAllLocationMusic(args ...string) ([]Music, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return fmt.Errorf("you must be logged in to do that")
+		return nil, err
 	}
 
 	if len(args) < 1 {
-		return fmt.Errorf("please specify a location ID")
+		return nil, fmt.Errorf("please specify a location ID")
 	}
 
 	locationID, err := uuid.Parse(args[0])
 	if err != nil {
-		return fmt.Errorf("invalid location ID: %v", err)
+		return nil, fmt.Errorf("invalid location ID: %v", err)
 	}
 
 	url := session.BaseURL + "locations/" + locationID.String() + "/music"
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
+		return nil, fmt.Errorf("error creating request: %v", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return nil, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("error getting music: %v", res.Status)
+		return nil, fmt.Errorf("error getting music: %w", newAPIError(res))
 	}
 
 	var musicList []Music
 	if err := json.NewDecoder(res.Body).Decode(&musicList); err != nil {
-		return fmt.Errorf("error decoding response: %v", err)
+		return nil, fmt.Errorf("error decoding response: %v", err)
 	}
 
-	for _, music := range musicList {
-		fmt.Printf("ID: %s\n", music.ID)
-		fmt.Printf("Title: %s\n", music.Title)
-		fmt.Printf("Artist: %s\n", music.Artist)
-		fmt.Printf("Genre: %s\n", music.Genre)
-		fmt.Printf("Format: %s\n", music.Format)
-		fmt.Printf("Release Date: %s\n", music.ReleaseDate)
-		fmt.Println()
-	}
-	return nil
+	return musicList, nil
 }
 
-func (session *Session) GetMusicByID(args ...string) error {
+func (session *Session) GetMusicByID(args ...string) (Music, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return err
+		return Music{}, err
 	}
 
 	if len(args) < 1 {
-		return fmt.Errorf("please specify a music ID")
+		return Music{}, fmt.Errorf("please specify a music ID")
 	}
 
 	musicUUID, err := uuid.Parse(args[0])
 	if err != nil {
-		return fmt.Errorf("invalid music ID: %v", err)
+		return Music{}, fmt.Errorf("invalid music ID: %v", err)
 	}
 
 	url := session.BaseURL + "music/" + musicUUID.String()
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
+		return Music{}, fmt.Errorf("error creating request: %v", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return Music{}, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("error getting music: %v", res.Status)
+		return Music{}, fmt.Errorf("error getting music: %w", newAPIError(res))
 	}
 
 	var music Music
 	if err := json.NewDecoder(res.Body).Decode(&music); err != nil {
-		return fmt.Errorf("error decoding response: %v", err)
+		return Music{}, fmt.Errorf("error decoding response: %v", err)
 	}
 
-	fmt.Printf("Title: %s\n", music.Title)
-	fmt.Printf("Artist: %s\n", music.Artist)
-	fmt.Printf("Genre: %s\n", music.Genre)
-	fmt.Printf("Release Date: %s\n", music.ReleaseDate)
-	fmt.Printf("Format: %s\n", music.Format)
-	fmt.Printf("Barcode: %s\n", music.Barcode)
-
-	return nil
+	return music, nil
 }
 
-func (session *Session) SearchMusic(args ...string) error {
+func (session *Session) SearchMusic(args ...string) ([]Music, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(args) < 1 {
-		return fmt.Errorf("please provide a search query")
+		return nil, fmt.Errorf("please provide a search query")
 	}
 
 	if session.CurrentLocation == uuid.Nil {
-		return fmt.Errorf("no location set, please set a location first")
+		return nil, fmt.Errorf("no location set, please set a location first")
 	}
 
 	query := args[0]
@@ -285,40 +239,139 @@ func (session *Session) SearchMusic(args ...string) error {
 		"location_id": session.CurrentLocation.String(),
 	})
 	if err != nil {
-		return fmt.Errorf("error marshalling request: %v", err)
+		return nil, fmt.Errorf("error marshalling request: %v", err)
 	}
 
 	req, err := http.NewRequest("GET", url, bytes.NewBuffer(reqBody))
 	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
+		return nil, fmt.Errorf("error creating request: %v", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return nil, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("error searching music: %v", res.Status)
+		return nil, fmt.Errorf("error searching music: %w", newAPIError(res))
 	}
 
 	var musicList []Music
 	if err := json.NewDecoder(res.Body).Decode(&musicList); err != nil {
-		return fmt.Errorf("error decoding response: %v", err)
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	return musicList, nil
+}
+
+func (session *Session) UpdateMusicShelf(args ...string) error {
+	err := validateLoggedIn(session)
+	if err != nil {
+		return err
+	}
+
+	if len(args) < 2 {
+		return fmt.Errorf("please provide a music ID and a shelf ID")
+	}
+
+	musicID, err := uuid.Parse(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid music ID: %v", err)
+	}
+
+	shelfID, err := uuid.Parse(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid shelf ID: %v", err)
+	}
+
+	err = session.validateShelf(shelfID.String())
+	if err != nil {
+		return err
+	}
+
+	url := session.BaseURL + "music/" + musicID.String()
+
+	reqBody, err := json.Marshal(map[string]string{"shelf_id": shelfID.String()})
+	if err != nil {
+		return fmt.Errorf("error marshalling request: %v", err)
+	}
+
+	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	res, err := session.do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	return fmt.Errorf("error updating music shelf: %w", newAPIError(res))
+}
+
+// UpdateMusic sends a partial update of music: only the fields in changes,
+// keyed by their JSON names, are modified.
+func (session *Session) UpdateMusic(musicID uuid.UUID, changes map[string]any) error {
+	err := validateLoggedIn(session)
+	if err != nil {
+		return err
+	}
+
+	url := session.BaseURL + "music/" + musicID.String()
+
+	reqBody, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("error marshalling request: %v", err)
+	}
+
+	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
 	}
 
-	for _, music := range musicList {
-		fmt.Printf("Title: %s\n", music.Title)
-		fmt.Printf("Artist: %s\n", music.Artist)
-		fmt.Printf("Genre: %s\n", music.Genre)
-		fmt.Printf("Format: %s\n", music.Format)
-		fmt.Printf("Release Date: %s\n", music.ReleaseDate)
-		fmt.Println()
+	req.Header.Set("Content-Type", "application/json")
+	res, err := session.do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	return fmt.Errorf("error updating music: %w", newAPIError(res))
+}
+
+func (session *Session) DeleteMusic(musicID uuid.UUID) error {
+	err := validateLoggedIn(session)
+	if err != nil {
+		return err
+	}
+
+	url := session.BaseURL + "music/" + musicID.String()
+
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	res, err := session.do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNoContent || res.StatusCode == http.StatusOK {
+		return nil
 	}
 
-	return nil
+	return fmt.Errorf("error deleting music: %w", newAPIError(res))
 }
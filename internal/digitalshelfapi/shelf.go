@@ -9,18 +9,18 @@ import (
 	"github.com/google/uuid"
 )
 
-func (session *Session) CreateShelf(args ...string) error {
+func (session *Session) CreateShelf(args ...string) (Shelf, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return err
+		return Shelf{}, err
 	}
 
 	if session.CurrentLocation == uuid.Nil {
-		return fmt.Errorf("please set a current location first")
+		return Shelf{}, fmt.Errorf("please set a current location first")
 	}
 
 	if len(args) < 2 {
-		return fmt.Errorf("please specify a case ID and shelf name")
+		return Shelf{}, fmt.Errorf("please specify a case ID and shelf name")
 	}
 
 	type parameters struct {
@@ -33,7 +33,7 @@ func (session *Session) CreateShelf(args ...string) error {
 
 	caseUUID, err := uuid.Parse(caseID)
 	if err != nil {
-		return fmt.Errorf("invalid case ID")
+		return Shelf{}, fmt.Errorf("invalid case ID")
 	}
 
 	url := session.BaseURL + "shelves"
@@ -45,140 +45,214 @@ func (session *Session) CreateShelf(args ...string) error {
 
 	reqBody, err := json.Marshal(params)
 	if err != nil {
-		return err
+		return Shelf{}, err
 	}
 
 	req, err := http.NewRequest("POST", url, bytes.NewBuffer(reqBody))
 	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
+		return Shelf{}, fmt.Errorf("error creating request: %v", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return Shelf{}, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == http.StatusCreated {
-		fmt.Println("Shelf created successfully")
-		return nil
-	} else {
-		return fmt.Errorf("error creating shelf")
+	if res.StatusCode != http.StatusCreated {
+		return Shelf{}, fmt.Errorf("error creating shelf: %w", newAPIError(res))
 	}
+
+	var shelf Shelf
+	err = json.NewDecoder(res.Body).Decode(&shelf)
+	if err != nil {
+		return Shelf{}, err
+	}
+	return shelf, nil
 }
 
-func (session *Session) GetShelves(args ...string) error {
+func (session *Session) GetShelves(args ...string) ([]Shelf, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(args) < 1 {
-		return fmt.Errorf("please specify a case ID")
+		return nil, fmt.Errorf("please specify a case ID")
 	}
 
-	caseID := args[0]
-	err = session.ValidateCase(caseID)
+	caseID, err := uuid.Parse(args[0])
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("invalid case ID: %v", err)
+	}
+	err = session.ValidateCase(caseID.String())
+	if err != nil {
+		return nil, err
+	}
+	return session.GetCaseShelves(caseID)
+}
+
+// GetCaseShelves lists the shelves in a case of any location the user is a
+// member of.
+func (session *Session) GetCaseShelves(caseID uuid.UUID) ([]Shelf, error) {
+	err := validateLoggedIn(session)
+	if err != nil {
+		return nil, err
 	}
 
-	url := session.BaseURL + "cases/" + caseID + "/shelves"
+	url := session.BaseURL + "cases/" + caseID.String() + "/shelves"
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
+		return nil, fmt.Errorf("error creating request: %v", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return nil, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == http.StatusOK {
-		var shelves []Shelf
-		err = json.NewDecoder(res.Body).Decode(&shelves)
-		if err != nil {
-			return err
-		}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting shelves: %w", newAPIError(res))
+	}
 
-		for _, shelf := range shelves {
-			fmt.Printf("Name: %s, ID: %s\n", shelf.Name, shelf.ID)
-		}
-		return nil
-	} else {
-		return fmt.Errorf("error getting shelves")
+	var shelves []Shelf
+	err = json.NewDecoder(res.Body).Decode(&shelves)
+	if err != nil {
+		return nil, err
 	}
+	return shelves, nil
 }
 
-func (session *Session) validateShelf(shelfID string) error {
+func (session *Session) GetShelf(shelfID string) (Shelf, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return err
+		return Shelf{}, err
 	}
 
 	url := session.BaseURL + "shelves/" + shelfID
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return err
+		return Shelf{}, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		fmt.Println("error making request: ", err)
+		return Shelf{}, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == http.StatusOK {
-		var c Case
-		err = json.NewDecoder(res.Body).Decode(&c)
-		if err != nil {
-			return err
-		}
-		return nil
-	} else {
-		return fmt.Errorf("shelf not found")
+	if res.StatusCode != http.StatusOK {
+		return Shelf{}, fmt.Errorf("error getting shelf: %w", newAPIError(res))
+	}
+
+	var shelf Shelf
+	err = json.NewDecoder(res.Body).Decode(&shelf)
+	if err != nil {
+		return Shelf{}, err
 	}
+	return shelf, nil
 }
 
-func (session *Session) SetCurrentShelf(args ...string) error {
+func (session *Session) validateShelf(shelfID string) error {
+	_, err := session.GetShelf(shelfID)
+	return err
+}
+
+func (session *Session) SetCurrentShelf(args ...string) (Shelf, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return err
+		return Shelf{}, err
 	}
 
 	if len(args) < 1 {
-		return fmt.Errorf("please specify a shelf ID")
+		return Shelf{}, fmt.Errorf("please specify a shelf ID")
 	}
 
-	shelfIDStr := args[0]
-	shelfID, err := uuid.Parse(shelfIDStr)
+	shelfID, err := uuid.Parse(args[0])
 	if err != nil {
-		return fmt.Errorf("invalid shelf ID")
+		return Shelf{}, fmt.Errorf("invalid shelf ID")
 	}
 
-	// Validate the shelf ID
-	err = session.validateShelf(shelfIDStr)
+	shelf, err := session.GetShelf(shelfID.String())
 	if err != nil {
-		return fmt.Errorf("invalid shelf ID: %v", err)
+		return Shelf{}, fmt.Errorf("invalid shelf ID: %v", err)
 	}
 
-	err = session.validateShelf(shelfIDStr)
+	session.CurrentShelf = shelf.ID
+	session.CurrentShelfName = shelf.Name
+	session.CurrentCaseName = ""
+	c, err := session.GetCase(shelf.CaseID.String())
+	if err == nil {
+		session.CurrentCaseName = c.Name
+	}
+	return shelf, nil
+}
+
+// UpdateShelf sends a partial update of a shelf: only the fields in changes,
+// keyed by their JSON names, are modified.
+func (session *Session) UpdateShelf(shelfID uuid.UUID, changes map[string]any) error {
+	err := validateLoggedIn(session)
+	if err != nil {
+		return err
+	}
+
+	url := session.BaseURL + "shelves/" + shelfID.String()
+
+	reqBody, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("error marshalling request: %v", err)
+	}
+
+	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	res, err := session.do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	return fmt.Errorf("error updating shelf: %w", newAPIError(res))
+}
+
+func (session *Session) DeleteShelf(shelfID uuid.UUID) error {
+	err := validateLoggedIn(session)
 	if err != nil {
 		return err
 	}
 
-	session.CurrentShelf = shelfID
-	fmt.Printf("Current shelf set to %s\n", shelfID)
+	url := session.BaseURL + "shelves/" + shelfID.String()
+
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	res, err := session.do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNoContent || res.StatusCode == http.StatusOK {
+		if shelfID == session.CurrentShelf {
+			session.CurrentShelf = uuid.Nil
+			session.CurrentCaseName = ""
+			session.CurrentShelfName = ""
+		}
+		return nil
+	}
 
-	return nil
+	return fmt.Errorf("error deleting shelf: %w", newAPIError(res))
 }
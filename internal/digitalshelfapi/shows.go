@@ -29,35 +29,20 @@ func (session *Session) LookupShowBarcode(args ...string) (Show, error) {
 		return Show{}, fmt.Errorf("error creating request: %v", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return Show{}, fmt.Errorf("error making request: %v", err)
+		return Show{}, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == http.StatusNotFound {
-		return Show{}, fmt.Errorf("show not found")
-	}
-
 	if res.StatusCode != http.StatusOK {
-		return Show{}, fmt.Errorf("error looking up show: %v", res.Status)
+		return Show{}, fmt.Errorf("error looking up show: %w", newAPIError(res))
 	}
 
 	var show Show
 	if err := json.NewDecoder(res.Body).Decode(&show); err != nil {
 		return Show{}, fmt.Errorf("error decoding response: %v", err)
 	}
-	fmt.Println("Show found")
-	fmt.Printf("Title: %s\n", show.Title)
-	fmt.Printf("Season: %s\n", show.Season)
-	fmt.Printf("Genre: %s\n", show.Genre)
-	fmt.Printf("Actors: %s\n", show.Actors)
-	fmt.Printf("Writer: %s\n", show.Writer)
-	fmt.Printf("Director: %s\n", show.Director)
-	fmt.Printf("Format: %s\n", show.Format)
-	fmt.Printf("Release Date: %s\n", show.ReleaseDate)
 
 	return show, nil
 }
@@ -107,30 +92,27 @@ func (session *Session) AddShow(shelfID uuid.UUID, show Show) error {
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode == http.StatusCreated {
-		fmt.Println("Show added successfully")
 		return nil
 	}
 
-	return fmt.Errorf("error adding show: %v", res.Status)
+	return fmt.Errorf("error adding show: %w", newAPIError(res))
 }
 
-func (session *Session) GetShows(args ...string) error {
+func (session *Session) GetShows(args ...string) ([]Show, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(args) < 1 {
-		return fmt.Errorf("please specify a shelf ID")
+		return nil, fmt.Errorf("please specify a shelf ID")
 	}
 
 	shelfID := args[0]
@@ -139,155 +121,119 @@ func (session *Session) GetShows(args ...string) error {
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
+		return nil, fmt.Errorf("error creating request: %v", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return nil, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("error getting shows: %v", res.Status)
+		return nil, fmt.Errorf("error getting shows: %w", newAPIError(res))
 	}
 
 	var shows []Show
 	if err := json.NewDecoder(res.Body).Decode(&shows); err != nil {
-		return fmt.Errorf("error decoding response: %v", err)
+		return nil, fmt.Errorf("error decoding response: %v", err)
 	}
 
-	for _, show := range shows {
-		fmt.Printf("Title: %s\n", show.Title)
-		fmt.Printf("Season: %s\n", show.Season)
-		fmt.Printf("Genre: %s\n", show.Genre)
-		fmt.Printf("Actors: %s\n", show.Actors)
-		fmt.Printf("Writer: %s\n", show.Writer)
-		fmt.Printf("Director: %s\n", show.Director)
-		fmt.Printf("Format: %s\n", show.Format)
-		fmt.Printf("Release Date: %s\n", show.ReleaseDate)
-		fmt.Println()
-	}
-
-	return nil
+	return shows, nil
 }
 
-func (session *Session) GetAllLocationShows(args ...string) error {
+func (session *Session) GetAllLocationShows(args ...string) ([]Show, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return fmt.Errorf("you must be logged in to do that")
+		return nil, err
 	}
 
 	if len(args) < 1 {
-		return fmt.Errorf("please specify a location ID")
+		return nil, fmt.Errorf("please specify a location ID")
 	}
 
 	locationID, err := uuid.Parse(args[0])
 	if err != nil {
-		return fmt.Errorf("invalid location ID: %v", err)
+		return nil, fmt.Errorf("invalid location ID: %v", err)
 	}
 
 	url := session.BaseURL + "locations/" + locationID.String() + "/shows"
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
+		return nil, fmt.Errorf("error creating request: %v", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return nil, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("error getting shows: %v", res.Status)
+		return nil, fmt.Errorf("error getting shows: %w", newAPIError(res))
 	}
 
 	var shows []Show
 	if err := json.NewDecoder(res.Body).Decode(&shows); err != nil {
-		return fmt.Errorf("error decoding response: %v", err)
+		return nil, fmt.Errorf("error decoding response: %v", err)
 	}
 
-	for _, show := range shows {
-		fmt.Printf("ID: %s\n", show.ID)
-		fmt.Printf("Title: %s\n", show.Title)
-		fmt.Printf("Season: %s\n", show.Season)
-		fmt.Printf("Format: %s\n", show.Format)
-		fmt.Printf("Release Date: %s\n", show.ReleaseDate)
-		fmt.Println()
-	}
-	return nil
+	return shows, nil
 }
 
-func (session *Session) GetShow(args ...string) error {
+func (session *Session) GetShow(args ...string) (Show, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return err
+		return Show{}, err
 	}
 
 	if len(args) < 1 {
-		return fmt.Errorf("please specify a show ID")
+		return Show{}, fmt.Errorf("please specify a show ID")
 	}
 
 	showUUID, err := uuid.Parse(args[0])
 	if err != nil {
-		return fmt.Errorf("invalid show ID: %v", err)
+		return Show{}, fmt.Errorf("invalid show ID: %v", err)
 	}
 
 	url := session.BaseURL + "shows/" + showUUID.String()
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
+		return Show{}, fmt.Errorf("error creating request: %v", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return Show{}, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("error getting show: %v", res.Status)
+		return Show{}, fmt.Errorf("error getting show: %w", newAPIError(res))
 	}
 
 	var show Show
 	if err := json.NewDecoder(res.Body).Decode(&show); err != nil {
-		return fmt.Errorf("error decoding response: %v", err)
+		return Show{}, fmt.Errorf("error decoding response: %v", err)
 	}
 
-	fmt.Printf("Title: %s\n", show.Title)
-	fmt.Printf("Season: %s\n", show.Season)
-	fmt.Printf("Genre: %s\n", show.Genre)
-	fmt.Printf("Actors: %s\n", show.Actors)
-	fmt.Printf("Writer: %s\n", show.Writer)
-	fmt.Printf("Director: %s\n", show.Director)
-	fmt.Printf("Release Date: %s\n", show.ReleaseDate)
-	fmt.Printf("Barcode: %s\n", show.Barcode)
-	fmt.Printf("Format: %s\n", show.Format)
-
-	return nil
+	return show, nil
 }
 
-func (session *Session) SearchShows(args ...string) error {
+func (session *Session) SearchShows(args ...string) ([]Show, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(args) < 1 {
-		return fmt.Errorf("please provide a search query")
+		return nil, fmt.Errorf("please provide a search query")
 	}
 
 	if session.CurrentLocation == uuid.Nil {
-		return fmt.Errorf("no location set, please set a location first")
+		return nil, fmt.Errorf("no location set, please set a location first")
 	}
 
 	query := args[0]
@@ -299,43 +245,139 @@ func (session *Session) SearchShows(args ...string) error {
 		"location_id": session.CurrentLocation.String(),
 	})
 	if err != nil {
-		return fmt.Errorf("error marshalling request: %v", err)
+		return nil, fmt.Errorf("error marshalling request: %v", err)
 	}
 
 	req, err := http.NewRequest("GET", url, bytes.NewBuffer(reqBody))
 	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
+		return nil, fmt.Errorf("error creating request: %v", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+session.Token)
-
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return nil, fmt.Errorf("error making request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("error searching shows: %v", res.Status)
+		return nil, fmt.Errorf("error searching shows: %w", newAPIError(res))
 	}
 
 	var shows []Show
 	if err := json.NewDecoder(res.Body).Decode(&shows); err != nil {
-		return fmt.Errorf("error decoding response: %v", err)
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	return shows, nil
+}
+
+func (session *Session) UpdateShowShelf(args ...string) error {
+	err := validateLoggedIn(session)
+	if err != nil {
+		return err
+	}
+
+	if len(args) < 2 {
+		return fmt.Errorf("please provide a show ID and a shelf ID")
+	}
+
+	showID, err := uuid.Parse(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid show ID: %v", err)
+	}
+
+	shelfID, err := uuid.Parse(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid shelf ID: %v", err)
+	}
+
+	err = session.validateShelf(shelfID.String())
+	if err != nil {
+		return err
+	}
+
+	url := session.BaseURL + "shows/" + showID.String()
+
+	reqBody, err := json.Marshal(map[string]string{"shelf_id": shelfID.String()})
+	if err != nil {
+		return fmt.Errorf("error marshalling request: %v", err)
+	}
+
+	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	res, err := session.do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	return fmt.Errorf("error updating show shelf: %w", newAPIError(res))
+}
+
+// UpdateShow sends a partial update of a show: only the fields in changes,
+// keyed by their JSON names, are modified.
+func (session *Session) UpdateShow(showID uuid.UUID, changes map[string]any) error {
+	err := validateLoggedIn(session)
+	if err != nil {
+		return err
+	}
+
+	url := session.BaseURL + "shows/" + showID.String()
+
+	reqBody, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("error marshalling request: %v", err)
+	}
+
+	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
 	}
 
-	for _, show := range shows {
-		fmt.Printf("Title: %s\n", show.Title)
-		fmt.Printf("Season: %s\n", show.Season)
-		fmt.Printf("Genre: %s\n", show.Genre)
-		fmt.Printf("Actors: %s\n", show.Actors)
-		fmt.Printf("Writer: %s\n", show.Writer)
-		fmt.Printf("Director: %s\n", show.Director)
-		fmt.Printf("Format: %s\n", show.Format)
-		fmt.Printf("Release Date: %s\n", show.ReleaseDate)
-		fmt.Println()
+	req.Header.Set("Content-Type", "application/json")
+	res, err := session.do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	return fmt.Errorf("error updating show: %w", newAPIError(res))
+}
+
+func (session *Session) DeleteShow(showID uuid.UUID) error {
+	err := validateLoggedIn(session)
+	if err != nil {
+		return err
+	}
+
+	url := session.BaseURL + "shows/" + showID.String()
+
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+
+	res, err := session.do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNoContent || res.StatusCode == http.StatusOK {
+		return nil
 	}
 
-	return nil
+	return fmt.Errorf("error deleting show: %w", newAPIError(res))
 }
@@ -7,14 +7,14 @@ import (
 	"net/http"
 )
 
-func (session *Session) CreateUser(args ...string) error {
+func (session *Session) CreateUser(args ...string) (User, error) {
 	err := validateLoggedIn(session)
 	if err == nil {
-		return err
+		return User{}, fmt.Errorf("you are already logged in")
 	}
 
 	if len(args) < 3 {
-		return fmt.Errorf("please specify name, email, and password")
+		return User{}, fmt.Errorf("please specify name, email, and password")
 	}
 
 	name := args[0]
@@ -22,13 +22,13 @@ func (session *Session) CreateUser(args ...string) error {
 	password := args[2]
 
 	if name == "" {
-		return fmt.Errorf("name is required")
+		return User{}, fmt.Errorf("name is required")
 	}
 	if email == "" {
-		return fmt.Errorf("email is required")
+		return User{}, fmt.Errorf("email is required")
 	}
 	if password == "" {
-		return fmt.Errorf("password is required")
+		return User{}, fmt.Errorf("password is required")
 	}
 
 	url := session.BaseURL + "users"
@@ -47,39 +47,42 @@ func (session *Session) CreateUser(args ...string) error {
 
 	reqBody, err := json.Marshal(params)
 	if err != nil {
-		return fmt.Errorf("error marshalling request body: %v", err)
+		return User{}, fmt.Errorf("error marshalling request body: %v", err)
 	}
 
 	req, err := http.NewRequest("POST", url, bytes.NewReader(reqBody))
 	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
+		return User{}, fmt.Errorf("error creating request: %v", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 
 	res, err := session.DSAPIClient.HttpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return User{}, fmt.Errorf("error making request: %w", err)
 	}
+	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusCreated {
-		return fmt.Errorf("error creating user: %v", res.Status)
+		return User{}, fmt.Errorf("error creating user: %w", newAPIError(res))
 	}
 
-	fmt.Println("User created successfully")
-	fmt.Println("Please login with the new user credentials")
+	var user User
+	if err := json.NewDecoder(res.Body).Decode(&user); err != nil {
+		return User{}, fmt.Errorf("error decoding response: %v", err)
+	}
 
-	return nil
+	return user, nil
 }
 
-func (session *Session) SearchUsers(args ...string) error {
+func (session *Session) SearchUsers(args ...string) (User, error) {
 	err := validateLoggedIn(session)
 	if err != nil {
-		return err
+		return User{}, err
 	}
 
 	if len(args) < 1 {
-		return fmt.Errorf("please specify an email address to search for")
+		return User{}, fmt.Errorf("please specify an email address to search for")
 	}
 
 	email := args[0]
@@ -87,26 +90,25 @@ func (session *Session) SearchUsers(args ...string) error {
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
+		return User{}, fmt.Errorf("error creating request: %v", err)
 	}
 
-	req.Header.Add("Authorization", "Bearer "+session.Token)
-	res, err := session.DSAPIClient.HttpClient.Do(req)
+	res, err := session.do(req)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return User{}, fmt.Errorf("error making request: %w", err)
 	}
+	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("error searching for user: %v", res.Status)
+		return User{}, fmt.Errorf("error searching for user: %w", newAPIError(res))
 	}
 
 	resultUser := User{}
 
 	err = json.NewDecoder(res.Body).Decode(&resultUser)
 	if err != nil {
-		return fmt.Errorf("error decoding response: %v", err)
+		return User{}, fmt.Errorf("error decoding response: %v", err)
 	}
 
-	fmt.Printf("User found: %s (%s) ID: %s\n", resultUser.Name, resultUser.Email, resultUser.ID)
-	return nil
+	return resultUser, nil
 }
@@ -1,30 +1,76 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"log"
 	"os"
-	"time"
 
 	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
 	"github.com/joho/godotenv"
+	"golang.org/x/term"
 )
 
+// interactive is true when commands are typed into the REPL rather than
+// given as arguments or piped in as a script.
+var interactive bool
+
 func main() {
 	godotenv.Load()
-	dbURL := os.Getenv("API_BASE_URL")
-	if dbURL == "" {
-		log.Fatal("API_BASE_URL must be set")
+	profileName := flag.String("profile", "", "name of the profile to use")
+	output := flag.String("output", string(formatTable), "output format for listings: table, json, jsonl, csv or yaml")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [command [args...]]\n\n", os.Args[0])
+		fmt.Fprintf(flag.CommandLine.Output(), "Without a command, starts the interactive shell, or runs the\ncommands piped to stdin. Use '-' as the command to read a script from stdin.\n\nFlags:\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	format, err := parseOutputFormat(*output)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(exitUsage)
+	}
+	currentOutput = format
+
+	cfg, err := loadConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+	name, prof, err := selectProfile(cfg, *profileName)
+	if err != nil {
+		log.Fatal(err)
 	}
-	platform := os.Getenv("PLATFORM")
-	if platform != "dev" && platform != "prod" {
-		log.Fatal("PLATFORM must be set to either dev or prod")
+	if cfg.Prompt != "" {
+		err = setPromptTemplate(cfg.Prompt)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Ignoring the prompt in your config: %v\n", err)
+		}
 	}
 
-	session := digitalshelfapi.Session{
-		DSAPIClient: digitalshelfapi.NewClient(time.Second * 10),
-		BaseURL:     dbURL,
-		Platform:    platform,
+	args := flag.Args()
+	runScriptFromStdin := (len(args) == 1 && args[0] == "-") ||
+		(len(args) == 0 && !term.IsTerminal(int(os.Stdin.Fd())))
+	interactive = len(args) == 0 && !runScriptFromStdin
+
+	session := digitalshelfapi.Session{}
+	if len(args) > 0 && args[0] == completeCommandName {
+		if requested := completionProfile(args[1:]); requested != "" {
+			name, prof, err = selectProfile(cfg, requested)
+			if err != nil {
+				os.Exit(exitUsage)
+			}
+		}
+		loadCachedSession(&session, name, prof)
+		os.Exit(runOnce(&session, args))
 	}
+	startSession(&session, name, prof)
 
+	switch {
+	case runScriptFromStdin:
+		os.Exit(runScript(&session))
+	case len(args) > 0:
+		os.Exit(runOnce(&session, args))
+	}
 	startRepl(&session)
 }
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+)
+
+type outputFormat string
+
+const (
+	formatTable outputFormat = "table"
+	formatJSON  outputFormat = "json"
+	formatJSONL outputFormat = "jsonl"
+	formatCSV   outputFormat = "csv"
+	formatYAML  outputFormat = "yaml"
+)
+
+var outputFormats = []outputFormat{formatTable, formatJSON, formatJSONL, formatCSV, formatYAML}
+
+// currentOutput is the format listings are rendered in, set with --output
+// or 'set output'.
+var currentOutput = formatTable
+
+func outputFormatNames() []string {
+	names := make([]string, 0, len(outputFormats))
+	for _, format := range outputFormats {
+		names = append(names, string(format))
+	}
+	return names
+}
+
+func parseOutputFormat(name string) (outputFormat, error) {
+	for _, format := range outputFormats {
+		if string(format) == strings.ToLower(name) {
+			return format, nil
+		}
+	}
+	return "", usageErrorf("unknown output format: %s (use table, json, jsonl, csv or yaml)", name)
+}
+
+// tableColumns lists the fields shown in table output, by JSON name. The other
+// formats include every field.
+var tableColumns = map[reflect.Type][]string{
+	reflect.TypeOf(digitalshelfapi.Movie{}):              {"id", "title", "genre", "director", "format", "release_date"},
+	reflect.TypeOf(digitalshelfapi.Show{}):               {"id", "title", "season", "genre", "format", "release_date"},
+	reflect.TypeOf(digitalshelfapi.Book{}):               {"id", "title", "author", "genre", "publication_date"},
+	reflect.TypeOf(digitalshelfapi.Music{}):              {"id", "title", "artist", "genre", "format", "release_date"},
+	reflect.TypeOf(digitalshelfapi.Case{}):               {"id", "name"},
+	reflect.TypeOf(digitalshelfapi.Shelf{}):              {"id", "name", "case_id"},
+	reflect.TypeOf(digitalshelfapi.LocationMembership{}): {"location_id", "location_name", "joined_at"},
+	reflect.TypeOf(digitalshelfapi.LocationMember{}):     {"user_id", "name", "email", "role", "joined_at"},
+	reflect.TypeOf(digitalshelfapi.UserInvite{}):         {"location_id", "location_name", "invited_at"},
+	reflect.TypeOf(pendingInvite{}):                      {"number", "location_name", "invited_at"},
+	reflect.TypeOf(digitalshelfapi.User{}):               {"id", "name", "email"},
+	reflect.TypeOf(trashEntry{}):                         {"id", "type", "title", "shelf_id", "removed_at"},
+}
+
+type field struct {
+	name  string
+	value any
+}
+
+// recordFields returns the exported fields of a struct in declaration order,
+// named by their JSON tags so every format uses the API's field names.
+func recordFields(record any) []field {
+	v := reflect.ValueOf(record)
+	t := v.Type()
+	fields := make([]field, 0, t.NumField())
+	for i := range t.NumField() {
+		structField := t.Field(i)
+		name, _, _ := strings.Cut(structField.Tag.Get("json"), ",")
+		if !structField.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = structField.Name
+		}
+		fields = append(fields, field{name: name, value: v.Field(i).Interface()})
+	}
+	return fields
+}
+
+// renderList prints items in the current output format and remembers their
+// IDs for completion.
+func renderList[T any](items []T) error {
+	rememberRecordIDs(records(items))
+	return writeList(os.Stdout, currentOutput, items)
+}
+
+// renderItem prints a single item in the current output format. Tables show
+// every field of a single item, one per line.
+func renderItem[T any](item T) error {
+	rememberRecordIDs(records([]T{item}))
+	return writeItem(os.Stdout, currentOutput, item)
+}
+
+func writeList[T any](w io.Writer, format outputFormat, items []T) error {
+	if items == nil {
+		items = []T{}
+	}
+	switch format {
+	case formatJSON:
+		return writeJSON(w, items)
+	case formatJSONL:
+		encoder := json.NewEncoder(w)
+		for _, item := range items {
+			err := encoder.Encode(item)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case formatCSV:
+		return writeCSV(w, records(items))
+	case formatYAML:
+		return writeYAML(w, records(items))
+	default:
+		return writeTable(w, reflect.TypeOf(items).Elem(), records(items))
+	}
+}
+
+func writeItem[T any](w io.Writer, format outputFormat, item T) error {
+	switch format {
+	case formatJSON:
+		return writeJSON(w, item)
+	case formatJSONL:
+		return json.NewEncoder(w).Encode(item)
+	case formatCSV:
+		return writeCSV(w, records([]T{item}))
+	case formatYAML:
+		return writeYAMLFields(w, recordFields(item), "")
+	default:
+		for _, f := range recordFields(item) {
+			fmt.Fprintf(w, "%s: %s\n", fieldLabel(f.name), tableValue(f.value))
+		}
+		return nil
+	}
+}
+
+func records[T any](items []T) [][]field {
+	rows := make([][]field, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, recordFields(item))
+	}
+	return rows
+}
+
+func writeJSON(w io.Writer, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+func writeCSV(w io.Writer, rows [][]field) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	writer := csv.NewWriter(w)
+	header := make([]string, 0, len(rows[0]))
+	for _, f := range rows[0] {
+		header = append(header, f.name)
+	}
+	writer.Write(header)
+	for _, row := range rows {
+		values := make([]string, 0, len(row))
+		for _, f := range row {
+			values = append(values, scalarString(f.value))
+		}
+		writer.Write(values)
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeYAML(w io.Writer, rows [][]field) error {
+	if len(rows) == 0 {
+		_, err := fmt.Fprintln(w, "[]")
+		return err
+	}
+	for _, row := range rows {
+		err := writeYAMLFields(w, row, "- ")
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeYAMLFields writes one mapping. Values are JSON encoded, which is
+// always valid YAML and keeps strings quoted.
+func writeYAMLFields(w io.Writer, fields []field, prefix string) error {
+	indent := strings.Repeat(" ", len(prefix))
+	for i, f := range fields {
+		value, err := json.Marshal(f.value)
+		if err != nil {
+			return err
+		}
+		linePrefix := indent
+		if i == 0 {
+			linePrefix = prefix
+		}
+		fmt.Fprintf(w, "%s%s: %s\n", linePrefix, f.name, value)
+	}
+	return nil
+}
+
+func writeTable(w io.Writer, recordType reflect.Type, rows [][]field) error {
+	if len(rows) == 0 {
+		_, err := fmt.Fprintln(w, "No results")
+		return err
+	}
+
+	columns := tableColumns[recordType]
+	if columns == nil {
+		for _, f := range rows[0] {
+			columns = append(columns, f.name)
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	headers := make([]string, 0, len(columns))
+	for _, column := range columns {
+		headers = append(headers, strings.ToUpper(strings.ReplaceAll(column, "_", " ")))
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		values := make([]string, 0, len(columns))
+		for _, column := range columns {
+			for _, f := range row {
+				if f.name == column {
+					values = append(values, tableValue(f.value))
+					break
+				}
+			}
+		}
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	return tw.Flush()
+}
+
+// fieldLabel turns a JSON field name such as release_date into "Release Date".
+func fieldLabel(name string) string {
+	words := strings.Split(name, "_")
+	for i, word := range words {
+		if word == "id" {
+			words[i] = "ID"
+			continue
+		}
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// tableValue formats a value for people: dates without times, everything else
+// as it would appear in CSV.
+func tableValue(value any) string {
+	if t, ok := value.(time.Time); ok {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format("2006-01-02")
+	}
+	return scalarString(value)
+}
+
+// scalarString formats a value as its JSON encoding, without quotes for
+// strings.
+func scalarString(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	var s string
+	if json.Unmarshal(data, &s) == nil {
+		return s
+	}
+	return string(data)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+	"github.com/google/uuid"
+)
+
+func TestWriteList(t *testing.T) {
+	books := []digitalshelfapi.Book{
+		{
+			ID:              uuid.MustParse("2b1e0f3c-94a5-4b59-9f3e-2f6f3c0d9c11"),
+			Title:           "Dune, Part One",
+			Author:          "Frank Herbert",
+			Genre:           "Science Fiction",
+			Barcode:         "9780441013593",
+			ShelfID:         uuid.MustParse("9d2c1c1a-1c62-4a8e-8f0b-3a4c3b1f1e22"),
+			PublicationDate: time.Date(1965, 8, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	cases := []struct {
+		format   outputFormat
+		expected string
+	}{
+		{
+			format: formatTable,
+			expected: "ID                                    TITLE           AUTHOR         GENRE            PUBLICATION DATE\n" +
+				"2b1e0f3c-94a5-4b59-9f3e-2f6f3c0d9c11  Dune, Part One  Frank Herbert  Science Fiction  1965-08-01\n",
+		},
+		{
+			format: formatCSV,
+			expected: "id,title,author,genre,barcode,shelf_id,publication_date,created_at,updated_at\n" +
+				"2b1e0f3c-94a5-4b59-9f3e-2f6f3c0d9c11,\"Dune, Part One\",Frank Herbert,Science Fiction,9780441013593,9d2c1c1a-1c62-4a8e-8f0b-3a4c3b1f1e22,1965-08-01T00:00:00Z,0001-01-01T00:00:00Z,0001-01-01T00:00:00Z\n",
+		},
+		{
+			format:   formatJSONL,
+			expected: `{"id":"2b1e0f3c-94a5-4b59-9f3e-2f6f3c0d9c11","title":"Dune, Part One","author":"Frank Herbert","genre":"Science Fiction","barcode":"9780441013593","shelf_id":"9d2c1c1a-1c62-4a8e-8f0b-3a4c3b1f1e22","publication_date":"1965-08-01T00:00:00Z","created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}` + "\n",
+		},
+		{
+			format: formatYAML,
+			expected: "- id: \"2b1e0f3c-94a5-4b59-9f3e-2f6f3c0d9c11\"\n" +
+				"  title: \"Dune, Part One\"\n" +
+				"  author: \"Frank Herbert\"\n" +
+				"  genre: \"Science Fiction\"\n" +
+				"  barcode: \"9780441013593\"\n" +
+				"  shelf_id: \"9d2c1c1a-1c62-4a8e-8f0b-3a4c3b1f1e22\"\n" +
+				"  publication_date: \"1965-08-01T00:00:00Z\"\n" +
+				"  created_at: \"0001-01-01T00:00:00Z\"\n" +
+				"  updated_at: \"0001-01-01T00:00:00Z\"\n",
+		},
+	}
+
+	for _, c := range cases {
+		var buf bytes.Buffer
+		err := writeList(&buf, c.format, books)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.format, err)
+			continue
+		}
+		if buf.String() != c.expected {
+			t.Errorf("%s output:\n%s\nexpected:\n%s", c.format, buf.String(), c.expected)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+	"github.com/chzyer/readline"
+	"golang.org/x/term"
+)
+
+// stdin is shared by the REPL and every prompt, so input buffered by one
+// reader is never lost to another when commands are piped in.
+var stdin = bufio.NewReader(os.Stdin)
+
+// lineEditor provides editing, history and completion while the interactive
+// REPL runs. Prompts fall back to stdin when it is nil.
+var lineEditor *readline.Instance
+
+// errCancelled is returned by readLine when Ctrl-C discards the line.
+var errCancelled = errors.New("cancelled")
+
+// runningScript is set while a command from a script read on stdin runs. Its
+// prompts would take the script's next lines as answers, so they fail
+// instead.
+var runningScript bool
+
+var errScriptPrompt = usageErrorf("cannot ask for input while running a script from stdin: give every argument, and use --yes to skip confirmations")
+
+const historyLimit = 1000
+
+// newLineEditor starts a line editor whose history is kept in the state
+// directory, shared by every profile.
+func newLineEditor(session *digitalshelfapi.Session) (*readline.Instance, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return nil, err
+	}
+	err = os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, err
+	}
+	return readline.NewEx(&readline.Config{
+		HistoryFile:            filepath.Join(dir, "history"),
+		HistoryLimit:           historyLimit,
+		DisableAutoSaveHistory: true,
+		HistorySearchFold:      true,
+		AutoComplete:           &replCompleter{session: session},
+		InterruptPrompt:        "^C",
+		EOFPrompt:              "exit",
+	})
+}
+
+// readLine prints prompt and returns the next line of input without its
+// line ending.
+func readLine(prompt string) (string, error) {
+	if runningScript {
+		return "", errScriptPrompt
+	}
+	if lineEditor != nil {
+		lineEditor.SetPrompt(prompt)
+		line, err := lineEditor.Readline()
+		if errors.Is(err, readline.ErrInterrupt) {
+			return "", errCancelled
+		}
+		return line, err
+	}
+
+	fmt.Print(prompt)
+	line, err := stdin.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// readLineDefault reads a line that starts out as value, so it can be edited
+// in place. Without the line editor, the value is shown and kept if the
+// answer is empty.
+func readLineDefault(prompt, value string) (string, error) {
+	if lineEditor != nil {
+		lineEditor.SetPrompt(prompt)
+		line, err := lineEditor.ReadlineWithDefault(value)
+		if errors.Is(err, readline.ErrInterrupt) {
+			return "", errCancelled
+		}
+		return line, err
+	}
+
+	line, err := readLine(fmt.Sprintf("%s[%s] ", prompt, value))
+	if err != nil {
+		return "", err
+	}
+	if line == "" {
+		return value, nil
+	}
+	return line, nil
+}
+
+// readPassword prompts for a password without echoing it. When stdin is not
+// a terminal the password is read as a plain line instead.
+func readPassword(prompt string) (string, error) {
+	if runningScript {
+		return "", errScriptPrompt
+	}
+	if lineEditor != nil {
+		password, err := lineEditor.ReadPassword(prompt)
+		if errors.Is(err, readline.ErrInterrupt) {
+			return "", errCancelled
+		}
+		return string(password), err
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return readLine(prompt)
+	}
+
+	fmt.Print(prompt)
+	password, err := term.ReadPassword(fd)
+	fmt.Println()
+	if err != nil {
+		return "", err
+	}
+	return string(password), nil
+}
+
+// assumeYes is set by --yes for the command being run.
+var assumeYes bool
+
+// confirm asks a yes/no question and reports whether the answer was yes.
+// With --yes the question is skipped.
+func confirm(prompt string) (bool, error) {
+	if assumeYes {
+		return true, nil
+	}
+	answer, err := readLine(prompt + " (y/n) ")
+	if err != nil {
+		return false, err
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
+
+type promptField struct {
+	label string
+	value *string
+}
+
+// promptFields reads one line of input into each field in turn.
+func promptFields(fields []promptField) error {
+	for _, field := range fields {
+		value, err := readLine(field.label + ": ")
+		if err != nil {
+			return err
+		}
+		*field.value = value
+	}
+	return nil
+}
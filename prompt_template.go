@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+	"golang.org/x/term"
+)
+
+// defaultPrompt shows who is logged in and where items will be added, for
+// example "digitalshelf [prod] Alice Home/Hall Case/Top > ".
+const defaultPrompt = `digitalshelf [{{.Platform}}]{{if .User}} {{.User}}{{end}}` +
+	`{{if .Location}} {{.Location}}{{end}}{{if .Case}}/{{.Case}}{{end}}{{if .Shelf}}/{{.Shelf}}{{end}} > `
+
+const fallbackPrompt = "digitalshelf > "
+
+// promptTemplate renders the REPL prompt. It is set from the config or with
+// 'set prompt'.
+var promptTemplate = template.Must(template.New("prompt").Parse(defaultPrompt))
+
+// promptData is what a prompt template can show.
+type promptData struct {
+	User     string
+	Email    string
+	Profile  string
+	Platform string
+	Location string
+	Case     string
+	Shelf    string
+}
+
+// setPromptTemplate replaces the prompt template, or restores the default
+// when text is "default".
+func setPromptTemplate(text string) error {
+	if text == "default" {
+		text = defaultPrompt
+	}
+	tmpl, err := template.New("prompt").Parse(text)
+	if err != nil {
+		return usageErrorf("invalid prompt template: %v", err)
+	}
+	err = tmpl.Execute(&strings.Builder{}, promptData{})
+	if err != nil {
+		return usageErrorf("invalid prompt template: %v", err)
+	}
+	promptTemplate = tmpl
+	return nil
+}
+
+// renderPrompt fills in the prompt template from the session. The prod
+// platform is shown in red on terminals so it is hard to miss.
+func renderPrompt(session *digitalshelfapi.Session) string {
+	platform := session.Platform
+	if platform == "prod" && term.IsTerminal(int(os.Stdout.Fd())) {
+		platform = "\033[1;31m" + platform + "\033[0m"
+	}
+	data := promptData{
+		User:     session.User.Name,
+		Email:    session.User.Email,
+		Profile:  activeProfile,
+		Platform: platform,
+		Location: session.CurrentLocationName,
+		Case:     session.CurrentCaseName,
+		Shelf:    session.CurrentShelfName,
+	}
+
+	var prompt strings.Builder
+	err := promptTemplate.Execute(&prompt, data)
+	if err != nil {
+		return fallbackPrompt
+	}
+	return prompt.String()
+}
@@ -1,166 +1,837 @@
 package main
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"strings"
 
 	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+	"github.com/google/uuid"
 )
 
+// Commands are grouped by category in the help listing, in this order.
+const (
+	categoryAccount   = "Account"
+	categoryLocations = "Locations"
+	categoryItems     = "Items"
+	categoryShell     = "Shell"
+)
+
+var commandCategories = []string{categoryAccount, categoryLocations, categoryItems, categoryShell}
+
+// cliCommand is a command or one of its subcommands. A command either has a
+// callback, which runs with the declared args and flags, or subcommands, or
+// both when the subcommand is optional.
 type cliCommand struct {
 	name        string
+	category    string
 	description string
-	callback    func(*digitalshelfapi.Session, ...string) error
+	args        []argSpec
+	flags       []flagSpec
+	examples    []string
+	subcommands []cliCommand
+	// hidden commands are left out of help and completion.
+	hidden bool
+	// role is the least role in the current location needed to run the
+	// command, or "" if it does not change the location.
+	role     string
+	callback func(*digitalshelfapi.Session, commandArgs) error
 }
 
 func startRepl(session *digitalshelfapi.Session) {
-	scanner := bufio.NewScanner(os.Stdin)
+	editor, err := newLineEditor(session)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Line editing is unavailable: %v\n", err)
+	} else {
+		lineEditor = editor
+		defer func() {
+			lineEditor = nil
+			editor.Close()
+		}()
+	}
 
 	for {
-		print("digitalshelf > ")
-		scanner.Scan()
-		input := scanner.Text()
-		cleanedInput := cleanInput(input)
-
-		if len(cleanedInput) == 0 {
+		input, err := readLine(renderPrompt(session))
+		if errors.Is(err, errCancelled) {
 			continue
 		}
-
-		commandName := cleanedInput[0]
-		args := []string{}
-
-		if len(cleanedInput) > 1 {
-			args = cleanedInput[1:]
+		if err != nil {
+			fmt.Println()
+			return
 		}
-
-		command, exists := getCommands()[commandName]
-		if !exists {
-			fmt.Println("Invalid command")
-			continue
+		if lineEditor != nil && strings.TrimSpace(input) != "" {
+			lineEditor.SaveHistory(input)
 		}
 
-		err := command.callback(session, args...)
+		err = runCommand(session, cleanInput(input))
+		if errors.Is(err, errExit) {
+			return
+		}
 		if err != nil {
-			fmt.Println(err)
+			fmt.Println(formatError(err))
 		}
 	}
 }
 
+// runCommand runs the command named by the first words with the remaining
+// words as its arguments, then saves any change it made to the session.
+func runCommand(session *digitalshelfapi.Session, words []string) error {
+	if len(words) == 0 {
+		return nil
+	}
+
+	command, exists := getCommands()[words[0]]
+	if !exists {
+		return usageErrorf("Invalid command: %s", words[0])
+	}
+	command, path, words, err := findCommand(command, words[1:])
+	if err != nil {
+		return err
+	}
+	args, err := parseArgs(command, path, words)
+	if err != nil {
+		return err
+	}
+	if args.enabled("help") {
+		writeCommandHelp(os.Stdout, path, command)
+		return nil
+	}
+
+	if format := args.flag("format"); format != "" {
+		previous := currentOutput
+		currentOutput, _ = parseOutputFormat(format)
+		defer func() {
+			currentOutput = previous
+		}()
+	}
+	assumeYes = args.enabled("yes")
+	defer func() {
+		assumeYes = false
+	}()
+
+	err = checkRole(session, path, command)
+	if err != nil {
+		return err
+	}
+	err = command.callback(session, args)
+	saveErr := persistSession(session)
+	if saveErr != nil {
+		fmt.Fprintln(os.Stderr, saveErr)
+	}
+	return err
+}
+
+// formatError adds a hint to errors the user can act on.
+func formatError(err error) string {
+	var urlErr *url.Error
+	switch {
+	case errors.Is(err, digitalshelfapi.ErrNotLoggedIn), errors.Is(err, digitalshelfapi.ErrUnauthorized):
+		return fmt.Sprintf("%v\nPlease log in with 'login'", err)
+	case errors.Is(err, digitalshelfapi.ErrForbidden):
+		return fmt.Sprintf("%v\nYou do not have permission to do this in this location", err)
+	case errors.Is(err, digitalshelfapi.ErrServer):
+		return fmt.Sprintf("%v\nThe DigitalShelf server ran into a problem, please try again later", err)
+	case errors.As(err, &urlErr):
+		return fmt.Sprintf("%v\nCould not reach the DigitalShelf server", err)
+	}
+	return err.Error()
+}
+
 func cleanInput(text string) []string {
-	var words []string
+	words, _, _ := splitInput(text)
+	return normalizeWords(words)
+}
+
+// splitInput splits text into words at spaces. Double quotes group words
+// and a backslash escapes the next character. For completion it also
+// reports whether the last word is still being typed, and whether it is
+// inside quotes.
+func splitInput(text string) (words []string, partial bool, inQuotes bool) {
 	var currentWord strings.Builder
-	inQuotes := false
+	inWord := false
+	escaped := false
 
 	for _, char := range text {
-		switch char {
-		case ' ':
-			if inQuotes {
-				currentWord.WriteRune(char)
-			} else if currentWord.Len() > 0 {
-				words = append(words, currentWord.String())
-				currentWord.Reset()
-			}
-		case '"':
+		switch {
+		case escaped:
+			currentWord.WriteRune(char)
+			escaped = false
+		case char == '\\':
+			escaped = true
+			inWord = true
+		case char == '"':
 			inQuotes = !inQuotes
-			if !inQuotes && currentWord.Len() > 0 {
+			inWord = true
+		case char == ' ' && !inQuotes:
+			if inWord {
 				words = append(words, currentWord.String())
 				currentWord.Reset()
+				inWord = false
 			}
 		default:
 			currentWord.WriteRune(char)
+			inWord = true
 		}
 	}
 
-	if currentWord.Len() > 0 {
+	if inWord {
 		words = append(words, currentWord.String())
 	}
-	if len(words) > 0 {
-		words[0] = strings.ToLower(words[0])
+	return words, inWord, inQuotes
+}
+
+// normalizeWords lowercases the command and subcommand names. Arguments
+// are left as they were typed.
+func normalizeWords(words []string) []string {
+	if len(words) == 0 {
+		return words
 	}
-	if len(words) > 1 {
-		words[1] = strings.ToLower(words[1])
+	words[0] = strings.ToLower(words[0])
+	command, ok := getCommands()[words[0]]
+	if !ok {
+		return words
+	}
+	for i := 1; i < len(words); i++ {
+		sub := findSubcommand(command.subcommands, words[i])
+		if sub == nil {
+			break
+		}
+		words[i] = sub.name
+		command = *sub
 	}
 	return words
 }
 
+// yesFlag skips confirmation prompts.
+var yesFlag = flagSpec{name: "yes", kind: kindBool, description: "Answer yes to every confirmation"}
+
+// addCommand declares an 'add' subcommand for one item type.
+func addCommand(name, description string, add func(*digitalshelfapi.Session, uuid.UUID, string) error) cliCommand {
+	return cliCommand{
+		name:        name,
+		description: description,
+		args: []argSpec{
+			{name: "barcode", kind: kindBarcode},
+			{name: "shelf", optional: true, complete: completeShelves},
+		},
+		flags: []flagSpec{
+			{name: "shelf", complete: completeShelves, description: "Shelf to add to, instead of the current shelf"},
+			yesFlag,
+		},
+		role:     digitalshelfapi.RoleEditor,
+		callback: addItem(add),
+	}
+}
+
+// scanCommand declares a 'scan' subcommand for one item type.
+func scanCommand(name, description string, scan func(*digitalshelfapi.Session, commandArgs) error) cliCommand {
+	return cliCommand{
+		name:        name,
+		description: description,
+		args:        []argSpec{{name: "shelf", optional: true, complete: completeShelves}},
+		flags: []flagSpec{
+			{name: "shelf", complete: completeShelves, description: "Shelf to add to, instead of the current shelf"},
+			{name: "quiet", kind: kindBool, description: "Do not ring the terminal bell after each scan"},
+		},
+		role:     digitalshelfapi.RoleEditor,
+		callback: scan,
+	}
+}
+
+// updateCommand declares an 'update' subcommand for one item type.
+func updateCommand(name, description string, update func(*digitalshelfapi.Session, commandArgs) error) cliCommand {
+	return cliCommand{
+		name:        name,
+		description: description,
+		args: []argSpec{
+			{name: "id", kind: kindUUID},
+			{name: "changes", optional: true, variadic: true},
+		},
+		flags:    []flagSpec{yesFlag},
+		role:     digitalshelfapi.RoleEditor,
+		callback: update,
+	}
+}
+
+// removeCommand declares a 'remove' subcommand for one item type.
+func removeCommand(name, description string, remove func(*digitalshelfapi.Session, commandArgs) error) cliCommand {
+	return cliCommand{
+		name:        name,
+		description: description,
+		args:        []argSpec{{name: "ids", kind: kindUUID, optional: true, variadic: true}},
+		flags: []flagSpec{
+			{name: "shelf", complete: completeShelves, description: "Shelf to choose from when no IDs are given, instead of the current shelf"},
+			yesFlag,
+		},
+		role:     digitalshelfapi.RoleEditor,
+		callback: remove,
+	}
+}
+
+// moveCommand declares a 'move' subcommand for one item type.
+func moveCommand(name, description string, move func(*digitalshelfapi.Session, commandArgs) error) cliCommand {
+	return cliCommand{
+		name:        name,
+		description: description,
+		args: []argSpec{
+			{name: "id", kind: kindUUID},
+			{name: "shelf", complete: completeShelves},
+		},
+		role:     digitalshelfapi.RoleEditor,
+		callback: move,
+	}
+}
+
+// deleteCommand declares a 'delete' subcommand for one kind of container.
+func deleteCommand(name, description, role string, complete func(*digitalshelfapi.Session) []string, remove func(*digitalshelfapi.Session, commandArgs) error) cliCommand {
+	return cliCommand{
+		name:        name,
+		description: description,
+		args:        []argSpec{{name: name, complete: complete}},
+		flags: []flagSpec{
+			{name: "cascade", kind: kindBool, description: "Delete everything in the " + name + " too. Unlike 'remove', this skips the trash and cannot be undone"},
+			{name: "move-to", complete: complete, description: "Move the contents to this " + name + " before deleting"},
+			yesFlag,
+		},
+		role:     role,
+		callback: remove,
+	}
+}
+
 func getCommands() map[string]cliCommand {
 	return map[string]cliCommand{
 		"exit": {
 			name:        "exit",
+			category:    categoryShell,
 			description: "Exit the DigitalShelf",
 			callback:    commandExit,
 		},
+		"completion": {
+			name:        "completion",
+			category:    categoryShell,
+			description: "Print a shell completion script",
+			examples: []string{
+				"source <(digitalshelf completion bash)",
+				"digitalshelf completion zsh > \"${fpath[1]}/_digitalshelf\"",
+				"digitalshelf completion fish > ~/.config/fish/completions/digitalshelf.fish",
+			},
+			subcommands: []cliCommand{
+				{name: "bash", description: "Print the bash completion script", callback: completionBash},
+				{name: "zsh", description: "Print the zsh completion script", callback: completionZsh},
+				{name: "fish", description: "Print the fish completion script", callback: completionFish},
+			},
+		},
+		completeCommandName: {
+			name:     completeCommandName,
+			hidden:   true,
+			args:     []argSpec{{name: "words", optional: true, variadic: true}},
+			callback: commandComplete,
+		},
 		"help": {
 			name:        "help",
-			description: "Show available commands",
+			category:    categoryShell,
+			description: "Show available commands, or details of one command",
+			args:        []argSpec{{name: "command", optional: true, variadic: true}},
+			examples:    []string{"help", "help add", "help get location", "add movie --help"},
 			callback:    commandHelp,
 		},
 		"login": {
 			name:        "login",
+			category:    categoryAccount,
 			description: "Login to your DigitalShelf account",
 			callback:    commandLogin,
 		},
 		"create": {
 			name:        "create",
-			description: "Create a new item/location",
-			callback:    commandCreate,
+			category:    categoryLocations,
+			description: "Create a new location, case or shelf",
+			subcommands: []cliCommand{
+				{
+					name:        "location",
+					description: "Create a location you own",
+					args:        []argSpec{{name: "name"}},
+					callback:    createLocation,
+				},
+				{
+					name:        "case",
+					description: "Create a case in the current location",
+					args:        []argSpec{{name: "name"}},
+					role:        digitalshelfapi.RoleEditor,
+					callback:    createCase,
+				},
+				{
+					name:        "shelf",
+					description: "Create a shelf in a case of the current location",
+					args:        []argSpec{{name: "case", complete: completeCases}, {name: "name"}},
+					examples:    []string{`create shelf "Living Room Case" "Top Shelf"`},
+					role:        digitalshelfapi.RoleEditor,
+					callback:    createShelf,
+				},
+			},
 		},
 		"join": {
 			name:        "join",
-			description: "Join a location",
+			category:    categoryLocations,
+			description: "Join a location you have been invited to",
+			args:        []argSpec{{name: "location", complete: completeInvites}},
+			flags:       []flagSpec{yesFlag},
+			examples:    []string{`join "Grandma's House"`},
 			callback:    commandJoin,
 		},
 		"get": {
 			name:        "get",
+			category:    categoryItems,
 			description: "Get information about an item/location",
-			callback:    commandGet,
+			subcommands: []cliCommand{
+				{name: "locations", description: "List the locations you are a member of", callback: getLocations},
+				{name: "invites", description: "List your invites to other locations", callback: getInvites},
+				{name: "cases", description: "List the cases in the current location", callback: getCases},
+				{name: "members", description: "List the members of the current location and their roles", callback: getMembers},
+				{
+					name:        "tree",
+					description: "Show the cases and shelves of the current location with item counts",
+					flags: []flagSpec{
+						{name: "depth", kind: kindInt, def: "3", description: "Levels to show: 0 for the location only, 1 for cases, 2 for shelves, 3 for items (default)"},
+						{name: "items", kind: kindBool, description: "List the items on each shelf"},
+					},
+					examples: []string{"get tree", "get tree --depth 1", "get tree --items"},
+					callback: getTree,
+				},
+				{
+					name:        "shelves",
+					description: "List the shelves in a case",
+					args:        []argSpec{{name: "case", complete: completeCases}},
+					examples:    []string{`get shelves "Living Room Case"`},
+					callback:    getShelves,
+				},
+				{
+					name:        "movies",
+					description: "List the movies on a shelf",
+					args:        []argSpec{{name: "shelf", complete: completeShelves}},
+					callback:    getMovies,
+				},
+				{
+					name:        "movie",
+					description: "Show the details of a movie",
+					args:        []argSpec{{name: "movie ID", kind: kindUUID}},
+					callback:    getMovie,
+				},
+				{
+					name:        "shows",
+					description: "List the shows on a shelf",
+					args:        []argSpec{{name: "shelf", complete: completeShelves}},
+					callback:    getShows,
+				},
+				{
+					name:        "show",
+					description: "Show the details of a show",
+					args:        []argSpec{{name: "show ID", kind: kindUUID}},
+					callback:    getShow,
+				},
+				{
+					name:        "books",
+					description: "List the books on a shelf",
+					args:        []argSpec{{name: "shelf", complete: completeShelves}},
+					callback:    getBooks,
+				},
+				{
+					name:        "book",
+					description: "Show the details of a book",
+					args:        []argSpec{{name: "book ID", kind: kindUUID}},
+					callback:    getBook,
+				},
+				{
+					name:        "music",
+					description: "List the music on a shelf",
+					args:        []argSpec{{name: "shelf", complete: completeShelves}},
+					callback:    getMusic,
+				},
+				{
+					name:        "location",
+					description: "List items across every shelf of the current location",
+					examples:    []string{"get location movies"},
+					subcommands: []cliCommand{
+						{name: "movies", description: "List every movie in the current location", callback: getLocationMovies},
+						{name: "shows", description: "List every show in the current location", callback: getLocationShows},
+						{name: "books", description: "List every book in the current location", callback: getLocationBooks},
+						{name: "music", description: "List all music in the current location", callback: getLocationMusic},
+					},
+				},
+			},
 		},
 		"set": {
 			name:        "set",
-			description: "Set a value or location",
-			callback:    commandSet,
+			category:    categoryLocations,
+			description: "Set the current location or shelf, or a preference",
+			subcommands: []cliCommand{
+				{
+					name:        "location",
+					description: "Set the location commands work in",
+					args:        []argSpec{{name: "location", complete: completeLocations}},
+					examples:    []string{`set location Home`},
+					callback:    setLocation,
+				},
+				{
+					name:        "shelf",
+					description: "Set the shelf new items are added to",
+					args:        []argSpec{{name: "shelf", complete: completeShelves}},
+					examples:    []string{`set shelf "Top Shelf"`},
+					callback:    setShelf,
+				},
+				{
+					name:        "output",
+					description: "Set the output format: table, json, jsonl, csv or yaml",
+					args:        []argSpec{{name: "format", kind: kindChoice, choices: outputFormatNames()}},
+					examples:    []string{"set output json"},
+					callback:    setOutput,
+				},
+				{
+					name:        "role",
+					description: "Change a member's role in the current location",
+					args: []argSpec{
+						{name: "member", complete: completeMembers},
+						{name: "role", kind: kindChoice, choices: []string{digitalshelfapi.RoleEditor, digitalshelfapi.RoleViewer}},
+					},
+					examples: []string{`set role "Sam" viewer`, "set role sam@example.com editor"},
+					role:     digitalshelfapi.RoleOwner,
+					callback: setRole,
+				},
+				{
+					name: "prompt",
+					description: "Set the prompt, or 'default' to restore it. Templates may use {{.User}}, {{.Email}}, " +
+						"{{.Profile}}, {{.Platform}}, {{.Location}}, {{.Case}} and {{.Shelf}}",
+					args:     []argSpec{{name: "template", variadic: true}},
+					examples: []string{`set prompt "{{.Location}}/{{.Shelf}} > "`, "set prompt default"},
+					callback: setPrompt,
+				},
+			},
 		},
 		"logout": {
 			name:        "logout",
-			description: "Logout of your DigitalShelf account. Use 'logout all' to logout of all sessions",
-			callback:    commandLogout,
+			category:    categoryAccount,
+			description: "Logout of your DigitalShelf account",
+			subcommands: []cliCommand{
+				{name: "all", description: "Logout of all sessions on every device", callback: logoutAll},
+			},
+			callback: commandLogout,
 		},
 		"changepassword": {
 			name:        "changepassword",
+			category:    categoryAccount,
 			description: "Change your password",
 			callback:    commandChangePassword,
 		},
 		"add": {
 			name:        "add",
-			description: "Add an item",
-			callback:    commandAdd,
+			category:    categoryItems,
+			description: "Look up a barcode and add the item to a shelf, working out its type when none is given",
+			args: []argSpec{
+				{name: "barcode", kind: kindBarcode},
+				{name: "shelf", optional: true, complete: completeShelves},
+			},
+			flags: []flagSpec{
+				{name: "shelf", complete: completeShelves, description: "Shelf to add to, instead of the current shelf"},
+				yesFlag,
+			},
+			examples: []string{
+				"add 9780316769488",
+				"add movie 883929106745",
+				`add movie 883929106745 --shelf "Top Shelf" --yes`,
+			},
+			subcommands: []cliCommand{
+				addCommand("movie", "Add a movie to the given shelf, or the current shelf", addMovie),
+				addCommand("show", "Add a show to the given shelf, or the current shelf", addShow),
+				addCommand("book", "Add a book to the given shelf, or the current shelf", addBook),
+				addCommand("music", "Add music to the given shelf, or the current shelf", addMusic),
+				addCommand("movie_bulk", "Add a movie many times, for benchmarking on dev servers", benchmarkCreateMovie),
+			},
+			role:     digitalshelfapi.RoleEditor,
+			callback: addDetected,
+		},
+		"scan": {
+			name:        "scan",
+			category:    categoryItems,
+			description: "Scan barcodes one after another, adding each item to a shelf",
+			examples:    []string{"scan movie", `scan book "Top Shelf" --quiet`},
+			subcommands: []cliCommand{
+				scanCommand("movie", "Scan movies onto the given shelf, or the current shelf", scanMovies),
+				scanCommand("show", "Scan shows onto the given shelf, or the current shelf", scanShows),
+				scanCommand("book", "Scan books onto the given shelf, or the current shelf", scanBooks),
+				scanCommand("music", "Scan music onto the given shelf, or the current shelf", scanMusic),
+			},
 		},
 		"invite": {
 			name:        "invite",
-			description: "Invite a user to your current location",
-			callback:    commandInvite,
+			category:    categoryLocations,
+			description: "Invite a user to your current location, or answer your own invites",
+			args:        []argSpec{{name: "user ID", kind: kindUUID}},
+			examples: []string{
+				"search users friend@example.com",
+				"invite 5f0c3b9e-1d2a-4c8b-9e7f-6a5b4c3d2e1f",
+				"invites",
+				"invite accept 1",
+			},
+			subcommands: []cliCommand{
+				{
+					name:        "accept",
+					description: "Join a location you have been invited to, by its number in 'invites'",
+					args:        []argSpec{{name: "invite", complete: completeInviteNumbers}},
+					flags:       []flagSpec{yesFlag},
+					callback:    inviteAccept,
+				},
+				{
+					name:        "decline",
+					description: "Turn down an invite, by its number in 'invites'",
+					args:        []argSpec{{name: "invite", complete: completeInviteNumbers}},
+					flags:       []flagSpec{yesFlag},
+					callback:    inviteDecline,
+				},
+			},
+			role:     digitalshelfapi.RoleOwner,
+			callback: commandInvite,
+		},
+		"invites": {
+			name:        "invites",
+			category:    categoryLocations,
+			description: "List your pending invites, numbered for 'invite accept' and 'invite decline'",
+			callback:    listInvites,
 		},
 		"register": {
 			name:        "register",
+			category:    categoryAccount,
 			description: "Create your DigitalShelf account",
 			callback:    commandRegister,
 		},
 		"remove": {
 			name:        "remove",
-			description: "Remove an item or member",
-			callback:    commandRemove,
+			category:    categoryLocations,
+			description: "Remove a member, invite or item",
+			examples: []string{
+				"remove movie 2b1e0f3c-94a5-4b59-9f3e-2f6f3c0d9c11",
+				`remove book --shelf "Top Shelf"`,
+			},
+			subcommands: []cliCommand{
+				{
+					name:        "member",
+					description: "Remove a member from the current location",
+					args:        []argSpec{{name: "user ID", kind: kindUUID}},
+					role:        digitalshelfapi.RoleOwner,
+					callback:    removeMember,
+				},
+				{
+					name:        "invite",
+					description: "Withdraw an invite to the current location",
+					args:        []argSpec{{name: "user ID", kind: kindUUID}},
+					role:        digitalshelfapi.RoleOwner,
+					callback:    removeInvite,
+				},
+				removeCommand("movie", "Delete movies, chosen by ID or from a shelf", removeMovies),
+				removeCommand("show", "Delete shows, chosen by ID or from a shelf", removeShows),
+				removeCommand("book", "Delete books, chosen by ID or from a shelf", removeBooks),
+				removeCommand("music", "Delete music, chosen by ID or from a shelf", removeMusic),
+			},
+		},
+		"transfer": {
+			name:        "transfer",
+			category:    categoryLocations,
+			description: "Hand a location over to another member",
+			subcommands: []cliCommand{
+				{
+					name:        "location",
+					description: "Make another member the owner of a location you own",
+					args: []argSpec{
+						{name: "location", complete: completeLocations},
+						{name: "member", complete: completeMembers},
+					},
+					flags: []flagSpec{
+						{name: "leave", kind: kindBool, description: "Leave the location once it has been transferred"},
+						yesFlag,
+					},
+					examples: []string{`transfer location Home "Sam" --leave`},
+					callback: transferLocation,
+				},
+			},
+		},
+		"trash": {
+			name:        "trash",
+			category:    categoryItems,
+			description: "List and restore removed items",
+			subcommands: []cliCommand{
+				{
+					name:        "list",
+					description: "List removed items",
+					callback:    trashList,
+				},
+				{
+					name:        "restore",
+					description: "Add removed items back to their shelves",
+					args:        []argSpec{{name: "ids", kind: kindUUID, variadic: true, complete: completeTrash}},
+					role:        digitalshelfapi.RoleEditor,
+					callback:    trashRestore,
+				},
+				{
+					name:        "empty",
+					description: "Forget every removed item",
+					flags:       []flagSpec{yesFlag},
+					callback:    trashEmpty,
+				},
+			},
+		},
+		"move": {
+			name:        "move",
+			category:    categoryItems,
+			description: "Move items to another shelf",
+			examples: []string{
+				`move book 9d2c1c1a-1c62-4a8e-8f0b-3a4c3b1f1e22 "Bottom Shelf"`,
+				`move shelf-contents "Top Shelf" "Bottom Shelf"`,
+			},
+			subcommands: []cliCommand{
+				moveCommand("movie", "Move a movie to another shelf", moveMovie),
+				moveCommand("show", "Move a show to another shelf", moveShow),
+				moveCommand("book", "Move a book to another shelf", moveBook),
+				moveCommand("music", "Move music to another shelf", moveMusic),
+				{
+					name:        "shelf-contents",
+					description: "Move everything on one shelf to another",
+					args: []argSpec{
+						{name: "from", complete: completeShelves},
+						{name: "to", complete: completeShelves},
+					},
+					flags:    []flagSpec{yesFlag},
+					role:     digitalshelfapi.RoleEditor,
+					callback: moveShelfContents,
+				},
+			},
+		},
+		"rename": {
+			name:        "rename",
+			category:    categoryLocations,
+			description: "Rename a location, case or shelf",
+			examples:    []string{`rename shelf "Top Shelf" "Top"`},
+			subcommands: []cliCommand{
+				{
+					name:        "location",
+					description: "Rename a location you own",
+					args:        []argSpec{{name: "location", complete: completeLocations}, {name: "new name"}},
+					callback:    renameLocation,
+				},
+				{
+					name:        "case",
+					description: "Rename a case in the current location",
+					args:        []argSpec{{name: "case", complete: completeCases}, {name: "new name"}},
+					role:        digitalshelfapi.RoleEditor,
+					callback:    renameCase,
+				},
+				{
+					name:        "shelf",
+					description: "Rename a shelf in the current location",
+					args:        []argSpec{{name: "shelf", complete: completeShelves}, {name: "new name"}},
+					role:        digitalshelfapi.RoleEditor,
+					callback:    renameShelf,
+				},
+			},
+		},
+		"delete": {
+			name:        "delete",
+			category:    categoryLocations,
+			description: "Delete a location, case or shelf; contents deleted with it cannot be restored",
+			examples: []string{
+				`delete shelf "Old Shelf" --move-to "Top Shelf"`,
+				`delete case "Garage Case" --cascade --yes`,
+			},
+			subcommands: []cliCommand{
+				deleteCommand("location", "Delete a location you own", "", completeLocations, deleteLocation),
+				deleteCommand("case", "Delete a case in the current location", digitalshelfapi.RoleEditor, completeCases, deleteCase),
+				deleteCommand("shelf", "Delete a shelf in the current location", digitalshelfapi.RoleEditor, completeShelves, deleteShelf),
+			},
 		},
 		"search": {
 			name:        "search",
+			category:    categoryItems,
 			description: "Search for items/users",
-			callback:    commandSearch,
+			subcommands: []cliCommand{
+				{
+					name:        "users",
+					description: "Find a user by email address",
+					args:        []argSpec{{name: "email"}},
+					callback:    searchUsers,
+				},
+				{
+					name:        "movies",
+					description: "Search movies in your locations",
+					args:        []argSpec{{name: "term"}},
+					examples:    []string{`search movies "star wars"`},
+					callback:    searchMovies,
+				},
+				{
+					name:        "shows",
+					description: "Search shows in your locations",
+					args:        []argSpec{{name: "term"}},
+					callback:    searchShows,
+				},
+				{
+					name:        "books",
+					description: "Search books in your locations",
+					args:        []argSpec{{name: "term"}},
+					callback:    searchBooks,
+				},
+				{
+					name:        "music",
+					description: "Search music in your locations",
+					args:        []argSpec{{name: "term"}},
+					callback:    searchMusic,
+				},
+			},
+		},
+		"profile": {
+			name:        "profile",
+			category:    categoryAccount,
+			description: "Manage profiles for different accounts and servers",
+			subcommands: []cliCommand{
+				{name: "list", description: "List profiles, marking the active one", callback: profileList},
+				{
+					name:        "use",
+					description: "Switch to a profile",
+					args:        []argSpec{{name: "name", complete: completeProfiles}},
+					callback:    profileUse,
+				},
+				{
+					name:        "add",
+					description: "Add a profile for a server",
+					args: []argSpec{
+						{name: "name"},
+						{name: "url"},
+						{name: "platform", kind: kindChoice, choices: []string{"dev", "prod"}},
+						{name: "default location", kind: kindUUID, optional: true},
+					},
+					examples: []string{"profile add work https://shelf.example.com/api/ prod"},
+					callback: profileAdd,
+				},
+				{
+					name:        "remove",
+					description: "Remove a profile and its saved session",
+					args:        []argSpec{{name: "name", complete: completeProfiles}},
+					callback:    profileRemove,
+				},
+			},
 		},
 		"update": {
 			name:        "update",
-			description: "Update an item",
-			callback:    commandUpdate,
+			category:    categoryItems,
+			description: "Change the details of an item, or move it to another shelf",
+			examples: []string{
+				`update movie 2b1e0f3c-94a5-4b59-9f3e-2f6f3c0d9c11 title="The Empire Strikes Back" release_date=1980-05-21`,
+				`update book 9d2c1c1a-1c62-4a8e-8f0b-3a4c3b1f1e22 shelf="Bottom Shelf"`,
+				"update show 5f0c3b9e-1d2a-4c8b-9e7f-6a5b4c3d2e1f",
+			},
+			subcommands: []cliCommand{
+				updateCommand("movie", "Change fields of a movie, or edit each field when none are given", updateMovie),
+				updateCommand("show", "Change fields of a show, or edit each field when none are given", updateShow),
+				updateCommand("book", "Change fields of a book, or edit each field when none are given", updateBook),
+				updateCommand("music", "Change fields of music, or edit each field when none are given", updateMusic),
+			},
 		},
 	}
 }
@@ -23,7 +23,23 @@ func TestCleanInput(t *testing.T) {
 		},
 		{
 			input:    "  HellO  World  ",
-			expected: []string{"hello", "world"},
+			expected: []string{"hello", "World"},
+		},
+		{
+			input:    `JOIN "Grandma's House"`,
+			expected: []string{"join", "Grandma's House"},
+		},
+		{
+			input:    `Get Shelves "Living Room"`,
+			expected: []string{"get", "shelves", "Living Room"},
+		},
+		{
+			input:    `set shelf "Top Shelf"`,
+			expected: []string{"set", "shelf", "Top Shelf"},
+		},
+		{
+			input:    `get shelves Living\ Room`,
+			expected: []string{"get", "shelves", "Living Room"},
 		},
 	}
 
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+	"github.com/google/uuid"
+)
+
+// choice is something that can be referred to by name.
+type choice struct {
+	id    uuid.UUID
+	name  string
+	label string
+}
+
+// resolveLocation returns the ID of one of the user's locations, given either
+// its ID or its name.
+func resolveLocation(session *digitalshelfapi.Session, ref string) (uuid.UUID, error) {
+	if id, err := uuid.Parse(ref); err == nil {
+		return id, nil
+	}
+	locations, err := session.GetUserLocations()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	choices := make([]choice, 0, len(locations))
+	for _, location := range locations {
+		choices = append(choices, choice{id: location.LocationID, name: location.LocationName})
+	}
+	return resolveName("location", ref, choices)
+}
+
+// resolveInvite returns the ID of a location the user has been invited to,
+// given either its ID or its name.
+func resolveInvite(session *digitalshelfapi.Session, ref string) (uuid.UUID, error) {
+	if id, err := uuid.Parse(ref); err == nil {
+		return id, nil
+	}
+	invites, err := session.GetUserInvites()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	choices := make([]choice, 0, len(invites))
+	for _, invite := range invites {
+		choices = append(choices, choice{id: invite.LocationID, name: invite.LocationName})
+	}
+	return resolveName("invite to a location", ref, choices)
+}
+
+// resolveCase returns the ID of a case in the current location, given either
+// its ID or its name.
+func resolveCase(session *digitalshelfapi.Session, ref string) (uuid.UUID, error) {
+	if id, err := uuid.Parse(ref); err == nil {
+		return id, nil
+	}
+	cases, err := session.GetCases()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	choices := make([]choice, 0, len(cases))
+	for _, c := range cases {
+		choices = append(choices, choice{id: c.ID, name: c.Name})
+	}
+	return resolveName("case", ref, choices)
+}
+
+// resolveShelf returns the ID of a shelf in the current location, given
+// either its ID or its name. Shelves in different cases may share a name, so
+// they are listed with their case when there is a choice to make.
+func resolveShelf(session *digitalshelfapi.Session, ref string) (uuid.UUID, error) {
+	if id, err := uuid.Parse(ref); err == nil {
+		return id, nil
+	}
+	cases, err := session.GetCases()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	var choices []choice
+	for _, c := range cases {
+		shelves, err := session.GetShelves(c.ID.String())
+		if err != nil {
+			return uuid.Nil, err
+		}
+		for _, shelf := range shelves {
+			choices = append(choices, choice{
+				id:    shelf.ID,
+				name:  shelf.Name,
+				label: fmt.Sprintf("%s (in %s)", shelf.Name, c.Name),
+			})
+		}
+	}
+	return resolveName("shelf", ref, choices)
+}
+
+// resolveMember returns a member of the current location, given their ID,
+// name or email address.
+func resolveMember(session *digitalshelfapi.Session, ref string) (digitalshelfapi.LocationMember, error) {
+	if session.CurrentLocation == uuid.Nil {
+		return digitalshelfapi.LocationMember{}, fmt.Errorf("please set a location first")
+	}
+	return resolveMemberOf(session, session.CurrentLocation, ref)
+}
+
+// resolveMemberOf returns a member of any of the user's locations.
+func resolveMemberOf(session *digitalshelfapi.Session, locationID uuid.UUID, ref string) (digitalshelfapi.LocationMember, error) {
+	members, err := session.GetLocationMembers(locationID)
+	if err != nil {
+		return digitalshelfapi.LocationMember{}, err
+	}
+	id, err := uuid.Parse(ref)
+	if err != nil {
+		choices := make([]choice, 0, len(members))
+		for _, member := range members {
+			name := member.Name
+			if strings.EqualFold(member.Email, ref) {
+				name = ref
+			}
+			choices = append(choices, choice{
+				id:    member.UserID,
+				name:  name,
+				label: fmt.Sprintf("%s <%s>", member.Name, member.Email),
+			})
+		}
+		id, err = resolveName("member", ref, choices)
+		if err != nil {
+			return digitalshelfapi.LocationMember{}, err
+		}
+	}
+	for _, member := range members {
+		if member.UserID == id {
+			return member, nil
+		}
+	}
+	return digitalshelfapi.LocationMember{}, fmt.Errorf("%s is not a member of the location", ref)
+}
+
+// resolveName finds the choice with the given name, ignoring case. When more
+// than one matches, the user picks one.
+func resolveName(kind, name string, choices []choice) (uuid.UUID, error) {
+	var matches []choice
+	for _, c := range choices {
+		if strings.EqualFold(c.name, name) {
+			matches = append(matches, c)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return uuid.Nil, fmt.Errorf("no %s named %q", kind, name)
+	case 1:
+		return matches[0].id, nil
+	}
+
+	if !interactive {
+		return uuid.Nil, fmt.Errorf("more than one %s is named %q, please use its ID", kind, name)
+	}
+	fmt.Printf("More than one %s is named %q:\n", kind, name)
+	labels := make([]string, 0, len(matches))
+	for _, match := range matches {
+		label := match.label
+		if label == "" {
+			label = match.name
+		}
+		labels = append(labels, fmt.Sprintf("%s  %s", label, match.id))
+	}
+	i, err := pick(labels)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return matches[i].id, nil
+}
+
+// pick lists the options with numbers and returns the index of the one the
+// user chooses.
+func pick(options []string) (int, error) {
+	for i, option := range options {
+		fmt.Printf("  %d) %s\n", i+1, option)
+	}
+	for {
+		answer, err := readLine(fmt.Sprintf("Choose 1-%d: ", len(options)))
+		if err != nil {
+			return 0, err
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(answer))
+		if err == nil && n >= 1 && n <= len(options) {
+			return n - 1, nil
+		}
+		fmt.Println("Please enter one of the numbers above")
+	}
+}
+
+// pickMany lists the options with numbers and returns the indexes of the
+// ones the user chooses, given as numbers and ranges such as "1,3-5", or
+// "all".
+func pickMany(options []string) ([]int, error) {
+	for i, option := range options {
+		fmt.Printf("  %d) %s\n", i+1, option)
+	}
+	for {
+		answer, err := readLine(fmt.Sprintf("Choose from 1-%d, e.g. 1,3-5 or all: ", len(options)))
+		if err != nil {
+			return nil, err
+		}
+		chosen, err := parseSelection(answer, len(options))
+		if err == nil {
+			return chosen, nil
+		}
+		fmt.Println(err)
+	}
+}
+
+// parseSelection parses a list of numbers and ranges between 1 and n into
+// zero-based indexes, in order and without duplicates.
+func parseSelection(answer string, n int) ([]int, error) {
+	answer = strings.TrimSpace(answer)
+	if strings.EqualFold(answer, "all") {
+		chosen := make([]int, n)
+		for i := range chosen {
+			chosen[i] = i
+		}
+		return chosen, nil
+	}
+
+	seen := map[int]bool{}
+	var chosen []int
+	for _, part := range strings.Split(answer, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		from, to, isRange := strings.Cut(part, "-")
+		if !isRange {
+			to = from
+		}
+		first, err := strconv.Atoi(strings.TrimSpace(from))
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number or range", part)
+		}
+		last, err := strconv.Atoi(strings.TrimSpace(to))
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number or range", part)
+		}
+		if first < 1 || last > n || first > last {
+			return nil, fmt.Errorf("%q is not between 1 and %d", part, n)
+		}
+		for i := first - 1; i < last; i++ {
+			if !seen[i] {
+				seen[i] = true
+				chosen = append(chosen, i)
+			}
+		}
+	}
+	if len(chosen) == 0 {
+		return nil, fmt.Errorf("please choose at least one")
+	}
+	return chosen, nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestResolveName(t *testing.T) {
+	home := uuid.MustParse("6f1c1d1e-5b0a-4c39-9a8e-0d6c9c1f2a01")
+	office := uuid.MustParse("0b7e8a3c-2d4f-4e6a-8c1b-9f3e5d7a4b02")
+	choices := []choice{
+		{id: home, name: "Home"},
+		{id: office, name: "Office"},
+		{id: uuid.New(), name: "Garage"},
+		{id: uuid.New(), name: "Garage"},
+	}
+
+	cases := []struct {
+		name     string
+		expected uuid.UUID
+		wantErr  bool
+	}{
+		{name: "Home", expected: home},
+		{name: "office", expected: office},
+		{name: "Attic", wantErr: true},
+		{name: "Garage", wantErr: true},
+	}
+
+	for _, c := range cases {
+		id, err := resolveName("location", c.name, choices)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %s", c.name, id)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+			continue
+		}
+		if id != c.expected {
+			t.Errorf("%s: got %s, expected %s", c.name, id, c.expected)
+		}
+	}
+}
+
+func TestParseSelection(t *testing.T) {
+	cases := []struct {
+		answer   string
+		expected []int
+		wantErr  bool
+	}{
+		{answer: "2", expected: []int{1}},
+		{answer: "1, 3-5", expected: []int{0, 2, 3, 4}},
+		{answer: "4-5,1,5", expected: []int{3, 4, 0}},
+		{answer: "ALL", expected: []int{0, 1, 2, 3, 4}},
+		{answer: "", wantErr: true},
+		{answer: "0", wantErr: true},
+		{answer: "6", wantErr: true},
+		{answer: "4-2", wantErr: true},
+		{answer: "two", wantErr: true},
+	}
+
+	for _, c := range cases {
+		chosen, err := parseSelection(c.answer, 5)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("%q: expected an error, got %v", c.answer, chosen)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", c.answer, err)
+			continue
+		}
+		if !slices.Equal(chosen, c.expected) {
+			t.Errorf("%q: got %v, expected %v", c.answer, chosen, c.expected)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+)
+
+// roleRanks orders the roles, so a role allows everything a lower one does.
+var roleRanks = map[string]int{
+	digitalshelfapi.RoleViewer: 1,
+	digitalshelfapi.RoleEditor: 2,
+	digitalshelfapi.RoleOwner:  3,
+}
+
+// hasRole reports whether the user's role in the current location is at
+// least role. When the server has not reported a role, everything is allowed
+// and the server has the final say.
+func hasRole(session *digitalshelfapi.Session, role string) bool {
+	if role == "" || session.CurrentRole == "" {
+		return true
+	}
+	return roleRanks[session.CurrentRole] >= roleRanks[role]
+}
+
+// checkRole returns an error if the user's role does not allow command.
+func checkRole(session *digitalshelfapi.Session, path string, command cliCommand) error {
+	if hasRole(session, command.role) {
+		return nil
+	}
+	return fmt.Errorf("'%s' needs the %s role in %s, but your role is %s", path, command.role, session.CurrentLocationName, session.CurrentRole)
+}
+
+// permittedCommands returns the commands without the ones the user's role
+// does not allow, so help and completion only offer what can be run.
+func permittedCommands(session *digitalshelfapi.Session) map[string]cliCommand {
+	commands := getCommands()
+	for name, command := range commands {
+		command, ok := permittedCommand(session, command)
+		if !ok {
+			delete(commands, name)
+			continue
+		}
+		commands[name] = command
+	}
+	return commands
+}
+
+// permittedCommand prunes one command. A command the user may not run is
+// still offered for any subcommands they may run, without its own arguments.
+func permittedCommand(session *digitalshelfapi.Session, command cliCommand) (cliCommand, bool) {
+	allowed := hasRole(session, command.role)
+	if !allowed {
+		command.args = nil
+		command.flags = nil
+		command.callback = nil
+	}
+	if len(command.subcommands) == 0 {
+		return command, allowed
+	}
+	var subcommands []cliCommand
+	for _, sub := range command.subcommands {
+		sub, ok := permittedCommand(session, sub)
+		if ok {
+			subcommands = append(subcommands, sub)
+		}
+	}
+	if len(subcommands) == 0 && command.callback == nil {
+		return command, false
+	}
+	command.subcommands = subcommands
+	return command, true
+}
+
+// requireOwner returns an error unless the user owns the location.
+func requireOwner(session *digitalshelfapi.Session, location digitalshelfapi.Location, action string) error {
+	if location.OwnerID == session.User.ID {
+		return nil
+	}
+	return fmt.Errorf("only the owner of %s can %s it", location.Name, action)
+}
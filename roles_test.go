@@ -0,0 +1,44 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+)
+
+func TestPermittedCommand(t *testing.T) {
+	command := cliCommand{
+		name: "remove",
+		subcommands: []cliCommand{
+			{name: "member", role: digitalshelfapi.RoleOwner},
+			{name: "movie", role: digitalshelfapi.RoleEditor},
+		},
+	}
+
+	cases := []struct {
+		role     string
+		expected []string
+	}{
+		{role: "", expected: []string{"member", "movie"}},
+		{role: digitalshelfapi.RoleOwner, expected: []string{"member", "movie"}},
+		{role: digitalshelfapi.RoleEditor, expected: []string{"movie"}},
+		{role: digitalshelfapi.RoleViewer, expected: nil},
+	}
+
+	for _, c := range cases {
+		session := &digitalshelfapi.Session{CurrentRole: c.role}
+		permitted, ok := permittedCommand(session, command)
+		if ok != (len(c.expected) > 0) {
+			t.Errorf("%q: got ok=%v, expected subcommands %v", c.role, ok, c.expected)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		names := subcommandList(permitted)
+		if !slices.Equal(names, c.expected) {
+			t.Errorf("%q: got %v, expected %v", c.role, names, c.expected)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+	"github.com/google/uuid"
+)
+
+// sessionState is the part of the session that survives a restart.
+type sessionState struct {
+	BaseURL         string               `json:"base_url"`
+	Token           string               `json:"token"`
+	RefreshToken    string               `json:"refresh_token"`
+	User            digitalshelfapi.User `json:"user"`
+	CurrentLocation uuid.UUID            `json:"current_location"`
+	CurrentShelf    uuid.UUID            `json:"current_shelf"`
+	LocationName    string               `json:"location_name,omitempty"`
+	CaseName        string               `json:"case_name,omitempty"`
+	ShelfName       string               `json:"shelf_name,omitempty"`
+	Role            string               `json:"role,omitempty"`
+}
+
+// savedState is what is currently on disk, so unchanged sessions are not
+// rewritten after every command.
+var savedState sessionState
+
+func newSessionState(session *digitalshelfapi.Session) sessionState {
+	token, refreshToken := session.Tokens()
+	return sessionState{
+		BaseURL:         session.BaseURL,
+		Token:           token,
+		RefreshToken:    refreshToken,
+		User:            session.User,
+		CurrentLocation: session.CurrentLocation,
+		CurrentShelf:    session.CurrentShelf,
+		LocationName:    session.CurrentLocationName,
+		CaseName:        session.CurrentCaseName,
+		ShelfName:       session.CurrentShelfName,
+		Role:            session.CurrentRole,
+	}
+}
+
+// stateDir returns $XDG_STATE_HOME/digitalshelf, defaulting to
+// ~/.local/state/digitalshelf.
+func stateDir() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "digitalshelf"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "state", "digitalshelf"), nil
+}
+
+// stateFilePath returns where the session of the named profile is saved.
+func stateFilePath(profileName string) (string, error) {
+	err := validateProfileName(profileName)
+	if err != nil {
+		return "", err
+	}
+	dir, err := stateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "sessions", profileName+".json"), nil
+}
+
+func loadState() (sessionState, error) {
+	path, err := stateFilePath(activeProfile)
+	if err != nil {
+		return sessionState{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return sessionState{}, err
+	}
+	var state sessionState
+	err = json.Unmarshal(data, &state)
+	if err != nil {
+		return sessionState{}, fmt.Errorf("error reading %s: %v", path, err)
+	}
+	return state, nil
+}
+
+func saveState(state sessionState) error {
+	path, err := stateFilePath(activeProfile)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// writeFileAtomic replaces path with data, readable only by the user, so a
+// crash never leaves a half-written file behind.
+func writeFileAtomic(path string, data []byte) error {
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	err = tmp.Chmod(0600)
+	if err == nil {
+		_, err = tmp.Write(data)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func removeState() error {
+	path, err := stateFilePath(activeProfile)
+	if err != nil {
+		return err
+	}
+	savedState = sessionState{}
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// persistSession writes the session to disk if it changed since it was last
+// saved, and removes the state file once the user is logged out.
+func persistSession(session *digitalshelfapi.Session) error {
+	state := newSessionState(session)
+	if state == savedState {
+		return nil
+	}
+	if state.Token == "" {
+		return removeState()
+	}
+	err := saveState(state)
+	if err != nil {
+		return fmt.Errorf("error saving session: %v", err)
+	}
+	savedState = state
+	return nil
+}
+
+// loadCachedSession restores the saved session without checking it with the
+// server, for shell completion which must answer quickly and quietly.
+func loadCachedSession(session *digitalshelfapi.Session, name string, prof profile) {
+	*session = newSession(prof)
+	activeProfile = name
+	session.DSAPIClient = digitalshelfapi.NewClient(completionTimeout)
+	state, err := loadState()
+	if err != nil || state.BaseURL != session.BaseURL {
+		return
+	}
+	session.SetTokens(state.Token, state.RefreshToken)
+	session.User = state.User
+	session.CurrentLocation = state.CurrentLocation
+	session.CurrentShelf = state.CurrentShelf
+	session.CurrentRole = state.Role
+	savedState = state
+}
+
+// restoreSession loads the saved session and checks it against the server,
+// dropping anything that is no longer valid.
+func restoreSession(session *digitalshelfapi.Session) {
+	state, err := loadState()
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		return
+	}
+	if state.BaseURL != session.BaseURL || state.Token == "" {
+		return
+	}
+
+	session.SetTokens(state.Token, state.RefreshToken)
+	session.User = state.User
+	savedState = state
+
+	_, err = session.GetUserLocations()
+	if err != nil {
+		if errors.Is(err, digitalshelfapi.ErrSessionExpired) || errors.Is(err, digitalshelfapi.ErrUnauthorized) {
+			fmt.Fprintln(os.Stderr, "Your saved session has expired, please log in again")
+			session.SetTokens("", "")
+			session.User = digitalshelfapi.User{}
+			removeState()
+			return
+		}
+		fmt.Fprintf(os.Stderr, "Could not verify your saved session: %v\n", err)
+		session.CurrentLocation = state.CurrentLocation
+		session.CurrentShelf = state.CurrentShelf
+		session.CurrentLocationName = state.LocationName
+		session.CurrentCaseName = state.CaseName
+		session.CurrentShelfName = state.ShelfName
+		session.CurrentRole = state.Role
+		return
+	}
+
+	if state.CurrentLocation != uuid.Nil {
+		_, err = session.SetCurrentLocation(state.CurrentLocation.String())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Your saved location is no longer available: %v\n", err)
+		}
+	}
+	if state.CurrentShelf != uuid.Nil && session.CurrentLocation != uuid.Nil {
+		_, err = session.SetCurrentShelf(state.CurrentShelf.String())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Your saved shelf is no longer available: %v\n", err)
+		}
+	}
+
+	if session.LoggedIn() && interactive {
+		fmt.Printf("Welcome back, %s\n", session.User.Name)
+	}
+	persistSession(session)
+}
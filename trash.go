@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Rodabaugh/digitalshelf-cli/internal/digitalshelfapi"
+	"github.com/google/uuid"
+)
+
+// trashEntry is an item deleted with 'remove', kept so it can be restored.
+type trashEntry struct {
+	ID        uuid.UUID       `json:"id"`
+	Type      string          `json:"type"`
+	Title     string          `json:"title"`
+	ShelfID   uuid.UUID       `json:"shelf_id"`
+	RemovedAt time.Time       `json:"removed_at"`
+	Item      json.RawMessage `json:"item"`
+}
+
+// restorers add a trashed item back to its shelf, by item type.
+var restorers = map[string]func(*digitalshelfapi.Session, trashEntry) error{
+	"movie": restoreAs((*digitalshelfapi.Session).AddMovie),
+	"show":  restoreAs((*digitalshelfapi.Session).AddShow),
+	"book":  restoreAs((*digitalshelfapi.Session).AddBook),
+	"music": restoreAs((*digitalshelfapi.Session).AddMusic),
+}
+
+func restoreAs[T any](add func(*digitalshelfapi.Session, uuid.UUID, T) error) func(*digitalshelfapi.Session, trashEntry) error {
+	return func(session *digitalshelfapi.Session, entry trashEntry) error {
+		var item T
+		err := json.Unmarshal(entry.Item, &item)
+		if err != nil {
+			return fmt.Errorf("error reading trashed %s: %v", entry.Type, err)
+		}
+		return add(session, entry.ShelfID, item)
+	}
+}
+
+// trashFilePath returns where the trash of the active profile is kept. Like
+// the session, it is separate for each profile.
+func trashFilePath() (string, error) {
+	err := validateProfileName(activeProfile)
+	if err != nil {
+		return "", err
+	}
+	dir, err := stateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "trash", activeProfile+".json"), nil
+}
+
+func loadTrash() ([]trashEntry, error) {
+	path, err := trashFilePath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []trashEntry
+	err = json.Unmarshal(data, &entries)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %v", path, err)
+	}
+	return entries, nil
+}
+
+func saveTrash(entries []trashEntry) error {
+	path, err := trashFilePath()
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		err = os.Remove(path)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// newTrashEntry records an item of the given type as it was before it was
+// deleted.
+func newTrashEntry(kind string, item any) (trashEntry, error) {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return trashEntry{}, err
+	}
+	entry := trashEntry{
+		Type:      kind,
+		RemovedAt: time.Now(),
+		Item:      data,
+	}
+	entry.ID, entry.Title = itemIdentity(item)
+	for _, f := range recordFields(item) {
+		if f.name == "shelf_id" {
+			entry.ShelfID, _ = f.value.(uuid.UUID)
+		}
+	}
+	return entry, nil
+}